@@ -63,6 +63,8 @@ func main() {
 	fmt.Println("EmailIsValid:", valid)
 	valid = validate.EmailIsValid("userexample.com") // false
 	fmt.Println("EmailIsValid:", valid)
+	addr, err := validate.EmailParse("josé@bücher.de") // josé@bücher.de, xn--bcher-kva.de
+	fmt.Println("EmailParse:", addr, addr.ASCIIDomain, err)
 
 	// URL validation
 	valid = validate.URLIsValid("https://example.com") // true
@@ -94,7 +96,7 @@ func main() {
 		}
 		return nil
 	})
-	err := v.Validate(5) // nil
+	err = v.Validate(5) // nil
 	fmt.Println("Custom validation:", err)
 	err = v.Validate(-5) // error: value must be positive
 	fmt.Println("Custom validation:", err)
@@ -4,8 +4,11 @@ go 1.18
 
 require (
 	github.com/golangci/golangci-lint v1.53.3
-	github.com/progxeno/validate v1.0.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/exp v0.0.0-20230510235704-dd950f8aeaea
+	golang.org/x/net v0.17.0
+	golang.org/x/text v0.13.0
 )
 
 require (
@@ -18,7 +21,7 @@ require (
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
 	golang.org/x/mod v0.10.0 // indirect
-	golang.org/x/sys v0.8.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 	golang.org/x/tools v0.9.3 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+const (
+	cfuFormat     = "Cfu "
+	cfuSpoofMagic = 0x3845fdef
+)
+
+var errCFUInvalid = errors.New("not an ICU confusables.cfu file")
+
+// convertCFU writes the mappings of an ICU confusables.cfu data file, as
+// built by ICU's gencfu from confusables.txt, to w in the format written
+// by convert. The data file does not record the Unicode version nor the
+// type of the mappings, which are all MA since Unicode 9.0, so the version
+// must be supplied by the caller.
+func convertCFU(w io.Writer, data []byte, version string) error {
+	if len(data) < 24 || string(data[12:16]) != cfuFormat {
+		return errCFUInvalid
+	}
+	// The UDataInfo records the byte order of the data that follows.
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[8] != 0 {
+		order = binary.BigEndian
+	}
+	spoof := data[int(order.Uint16(data[0:])):]
+	if len(spoof) < 32 || order.Uint32(spoof) != cfuSpoofMagic {
+		return errCFUInvalid
+	}
+
+	// The SpoofDataHeader lists the offset and size of the sorted keys,
+	// the parallel string index and the UTF-16 string table.
+	var h [6]int
+	for i := range h {
+		h[i] = int(int32(order.Uint32(spoof[12+4*i:])))
+	}
+	keysOff, keysLen, indexOff, indexLen, tableOff, tableLen := h[0], h[1], h[2], h[3], h[4], h[5]
+	if keysLen != indexLen || keysOff < 0 || indexOff < 0 || tableOff < 0 ||
+		keysOff+4*keysLen > len(spoof) || indexOff+2*indexLen > len(spoof) || tableOff+2*tableLen > len(spoof) {
+		return errCFUInvalid
+	}
+	table := make([]uint16, tableLen)
+	for i := range table {
+		table[i] = order.Uint16(spoof[tableOff+2*i:])
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Generated by internal/cmd/genconfusables from\n# ICU confusables.cfu (Unicode %s)\n# DO NOT EDIT.\n#\n# Version: %s\n", version, version)
+	for i := 0; i < keysLen; i++ {
+		// Each key holds the source code point in its low 24 bits and the
+		// UTF-16 length of the prototype minus one in its high 8 bits. A
+		// prototype of length one is stored in the string index itself.
+		key := order.Uint32(spoof[keysOff+4*i:])
+		value := int(order.Uint16(spoof[indexOff+2*i:]))
+		n := int(key>>24) + 1
+		var units []uint16
+		if n == 1 {
+			units = []uint16{uint16(value)}
+		} else if value+n <= len(table) {
+			units = table[value : value+n]
+		} else {
+			return fmt.Errorf("key %d: string out of range", i)
+		}
+
+		fmt.Fprintf(bw, "%04X ;\t", key&0xffffff)
+		for j, r := range utf16.Decode(units) {
+			if j > 0 {
+				bw.WriteByte(' ')
+			}
+			fmt.Fprintf(bw, "%04X", r)
+		}
+		bw.WriteString(" ;\tMA\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command genconfusables converts the Unicode confusables.txt data file
+// into the table embedded by internal/pkg/resource. It reads the file
+// from -in if given and downloads it from -url otherwise. Without network
+// access, -cfu reads the same mappings from the confusables.cfu data file
+// that ICU compiles from confusables.txt; -unicode then names the Unicode
+// version of that ICU release.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const defaultURL = "https://www.unicode.org/Public/security/15.0.0/confusables.txt"
+
+func main() {
+	url := flag.String("url", defaultURL, "URL of confusables.txt")
+	in := flag.String("in", "", "read confusables.txt from this file instead of -url")
+	cfu := flag.String("cfu", "", "read the mappings from this ICU confusables.cfu file instead")
+	version := flag.String("unicode", "15.0.0", "Unicode version of the -cfu file")
+	out := flag.String("o", "confusables.txt", "output file")
+	flag.Parse()
+
+	if *cfu != "" {
+		data, err := os.ReadFile(*cfu)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := write(*out, func(w io.Writer) error { return convertCFU(w, data, *version) }); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	src, err := open(*in, *url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer src.Close()
+
+	origin := *url
+	if *in != "" {
+		origin = *in
+	}
+	if err := write(*out, func(w io.Writer) error { return convert(w, src, origin) }); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func write(path string, fn func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func open(path, url string) (io.ReadCloser, error) {
+	if path != "" {
+		return os.Open(path)
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// convert copies the mappings of src to w. The comments of the mappings
+// are dropped to keep the embedded table small, but the version and date
+// lines of the header are kept.
+func convert(w io.Writer, src io.Reader, origin string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Generated by internal/cmd/genconfusables from\n# %s\n# DO NOT EDIT.\n#\n", origin)
+
+	scanner := bufio.NewScanner(src)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, "# Version:") || strings.HasPrefix(line, "# Date:") {
+				fmt.Fprintln(bw, line)
+			}
+			continue
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 3 {
+			if strings.TrimSpace(line) != "" {
+				return fmt.Errorf("line %d: malformed mapping", n)
+			}
+			continue
+		}
+		fmt.Fprintf(bw, "%s ;\t%s ;\t%s\n",
+			strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), strings.TrimSpace(fields[2]))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,71 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:generate go run ../../cmd/genconfusables -o data/confusables.txt
+
+package resource
+
+import (
+	_ "embed"
+)
+
+var (
+	//go:embed data/disposable_domains.txt
+	DisposableDomains string
+
+	//go:embed data/role_accounts.txt
+	RoleAccounts string
+
+	// PublicSuffixList is a snapshot of https://publicsuffix.org/list/
+	// taken on 2023-02-09.
+	//go:embed data/public_suffix_list.dat
+	PublicSuffixList string
+
+	// Confusables holds the UTS #39 confusable mappings in the format of
+	// https://www.unicode.org/Public/security/latest/confusables.txt.
+	//go:embed data/confusables.txt
+	Confusables string
+
+	// ISO4217 lists the ISO 4217 currency codes with their minor units.
+	//go:embed data/iso4217.txt
+	ISO4217 string
+
+	// NumberFormats lists decimal and grouping symbols per locale.
+	//go:embed data/number_formats.txt
+	NumberFormats string
+
+	// ISO3166 lists the ISO 3166-1 country codes.
+	//go:embed data/iso3166.txt
+	ISO3166 string
+
+	// IBANFormats lists the IBAN length and BBAN structure per country.
+	//go:embed data/iban_formats.txt
+	IBANFormats string
+
+	// CardIIN lists payment card brands by IIN range.
+	//go:embed data/card_iin.txt
+	CardIIN string
+
+	// CardTestNumbers lists test card numbers of payment processors.
+	//go:embed data/card_test_numbers.txt
+	CardTestNumbers string
+)
@@ -0,0 +1,378 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+var (
+	ErrCardInvalid      = errors.New("invalid payment card")
+	ErrCardNumber       = fmt.Errorf("%w: malformed number", ErrCardInvalid)
+	ErrCardBrandUnknown = fmt.Errorf("%w: unknown brand", ErrCardInvalid)
+	ErrCardBrand        = fmt.Errorf("%w: brand not accepted", ErrCardInvalid)
+	ErrCardLength       = fmt.Errorf("%w: length not valid for brand", ErrCardInvalid)
+	ErrCardChecksum     = fmt.Errorf("%w: Luhn check failed", ErrCardInvalid)
+	ErrCardTestNumber   = fmt.Errorf("%w: test card number", ErrCardInvalid)
+	ErrCardCVC          = fmt.Errorf("%w: invalid CVC", ErrCardInvalid)
+	ErrCardExpiry       = fmt.Errorf("%w: invalid expiry date", ErrCardInvalid)
+	ErrCardExpired      = fmt.Errorf("%w: card expired", ErrCardInvalid)
+)
+
+// cardMaxExpiryYears limits how far in the future an expiry date may be.
+const cardMaxExpiryYears = 20
+
+var (
+	cardBrands     atomic.Value // *CardBrands
+	cardBrandsOnce sync.Once
+	cardTestOnce   sync.Once
+	cardTestPANs   map[string]struct{}
+)
+
+func init() {
+	RegisterRule("card", func(param string) (ValidationRule, error) {
+		var opts CardOptions
+		switch param {
+		case "":
+		case "no_test":
+			opts.RejectTestNumbers = true
+		default:
+			return nil, fmt.Errorf("%w: unknown card rule parameter %q", ErrCardInvalid, param)
+		}
+		return cardRule(func(s string) error {
+			_, err := CardNumberValidate(s, opts)
+			return err
+		}), nil
+	})
+	RegisterRule("card_brand", func(param string) (ValidationRule, error) {
+		opts := CardOptions{Brands: strings.Split(param, "|")}
+		for _, name := range opts.Brands {
+			if _, ok := CardBrandTable().Lookup(name); !ok {
+				return nil, fmt.Errorf("%w: %q", ErrCardBrandUnknown, name)
+			}
+		}
+		return cardRule(func(s string) error {
+			_, err := CardNumberValidate(s, opts)
+			return err
+		}), nil
+	})
+	RegisterRule("card_expiry", func(string) (ValidationRule, error) {
+		return cardRule(func(s string) error {
+			month, year, err := CardExpiryParse(s)
+			if err != nil {
+				return err
+			}
+			return CardExpiryValidate(month, year, CardOptions{})
+		}), nil
+	})
+	RegisterRule("cvc", stringRuleFactory(func(s string) bool {
+		return (len(s) == 3 || len(s) == 4) && checkDigitIsDigits(s)
+	}, ErrCardCVC))
+}
+
+// CardBrand describes the numbers issued by a card network.
+type CardBrand struct {
+	Name      string
+	Lengths   []int
+	CVCLength int
+	prefixes  []cardPrefix
+}
+
+// cardPrefix is an inclusive range of IIN prefixes of equal length.
+type cardPrefix struct {
+	lo, hi string
+}
+
+// CardBrands is an immutable table of card brands.
+type CardBrands struct {
+	brands []*CardBrand
+}
+
+// CardOptions configures the Card validators.
+type CardOptions struct {
+	// Brands restricts numbers to the named brands, e.g. "visa".
+	Brands []string
+	// RejectTestNumbers rejects well-known test numbers such as
+	// 4111111111111111, as production checkouts should.
+	RejectTestNumbers bool
+	// Now returns the current time for expiry checks. It defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// NewCardBrands reads brands with one per line: name, comma-separated IIN
+// prefixes or ranges such as "2221-2720", comma-separated lengths or
+// length ranges and the CVC length.
+func NewCardBrands(r io.Reader) (*CardBrands, error) {
+	t := &CardBrands{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: want 4 fields, got %d", n, len(fields))
+		}
+
+		b := &CardBrand{Name: fields[0]}
+		for _, spec := range strings.Split(fields[1], ",") {
+			lo, hi, isRange := strings.Cut(spec, "-")
+			if !isRange {
+				hi = lo
+			}
+			if !checkDigitIsDigits(lo) || len(lo) != len(hi) || !checkDigitIsDigits(hi) || lo > hi {
+				return nil, fmt.Errorf("line %d: invalid IIN range %q", n, spec)
+			}
+			b.prefixes = append(b.prefixes, cardPrefix{lo: lo, hi: hi})
+		}
+		for _, spec := range strings.Split(fields[2], ",") {
+			lo, hi, isRange := strings.Cut(spec, "-")
+			if !isRange {
+				hi = lo
+			}
+			from, err1 := strconv.Atoi(lo)
+			to, err2 := strconv.Atoi(hi)
+			if err1 != nil || err2 != nil || from < 8 || from > to || to > 19 {
+				return nil, fmt.Errorf("line %d: invalid length %q", n, spec)
+			}
+			for l := from; l <= to; l++ {
+				b.Lengths = append(b.Lengths, l)
+			}
+		}
+		cvc, err := strconv.Atoi(fields[3])
+		if err != nil || cvc < 3 || cvc > 4 {
+			return nil, fmt.Errorf("line %d: invalid CVC length %q", n, fields[3])
+		}
+		b.CVCLength = cvc
+		t.brands = append(t.brands, b)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *CardBrands) Len() int {
+	return len(t.brands)
+}
+
+func (t *CardBrands) Lookup(name string) (*CardBrand, bool) {
+	for _, b := range t.brands {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// Detect returns the brand of the longest IIN prefix matching pan.
+func (t *CardBrands) Detect(pan string) (*CardBrand, bool) {
+	var brand *CardBrand
+	longest := 0
+	for _, b := range t.brands {
+		for _, p := range b.prefixes {
+			if len(p.lo) <= longest || len(pan) < len(p.lo) {
+				continue
+			}
+			if prefix := pan[:len(p.lo)]; prefix >= p.lo && prefix <= p.hi {
+				brand, longest = b, len(p.lo)
+			}
+		}
+	}
+	return brand, brand != nil
+}
+
+// CardBrandTable returns the brands used by the Card functions. The
+// embedded table is parsed on first use.
+func CardBrandTable() *CardBrands {
+	cardBrandsOnce.Do(func() {
+		cardBrands.CompareAndSwap(nil, mustCardBrands(resource.CardIIN))
+	})
+	return cardBrands.Load().(*CardBrands)
+}
+
+// CardSetBrandTable atomically replaces the brands used by the Card
+// functions.
+func CardSetBrandTable(t *CardBrands) {
+	cardBrands.Store(t)
+}
+
+func CardLoadBrandTable(r io.Reader) error {
+	t, err := NewCardBrands(r)
+	if err != nil {
+		return err
+	}
+	CardSetBrandTable(t)
+	return nil
+}
+
+func CardLoadBrandTableFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := CardLoadBrandTable(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// CardNumberValidate checks a card number (PAN), which may contain spaces
+// or hyphens between digits, and returns its brand. The brand's lengths
+// and the Luhn check digit are verified.
+func CardNumberValidate(pan string, opts CardOptions) (*CardBrand, error) {
+	digits := checkDigitStrip(pan, " -")
+	if !checkDigitIsDigits(digits) || !checkDigitIsDigits(pan[:1]) || !checkDigitIsDigits(pan[len(pan)-1:]) {
+		return nil, fmt.Errorf("%w: want digits", ErrCardNumber)
+	}
+	brand, ok := CardBrandTable().Detect(digits)
+	if !ok {
+		return nil, ErrCardBrandUnknown
+	}
+	if len(opts.Brands) > 0 && !containsString(opts.Brands, brand.Name) {
+		return nil, fmt.Errorf("%w: %s", ErrCardBrand, brand.Name)
+	}
+	if !cardContainsInt(brand.Lengths, len(digits)) {
+		return nil, fmt.Errorf("%w: %d digits for %s", ErrCardLength, len(digits), brand.Name)
+	}
+	if luhnSum(digits)%10 != 0 {
+		return nil, ErrCardChecksum
+	}
+	if opts.RejectTestNumbers && CardIsTestNumber(digits) {
+		return nil, ErrCardTestNumber
+	}
+	return brand, nil
+}
+
+func CardNumberIsValid(pan string) bool {
+	_, err := CardNumberValidate(pan, CardOptions{})
+	return err == nil
+}
+
+// CardIsTestNumber checks if pan is a published test card number.
+func CardIsTestNumber(pan string) bool {
+	cardTestOnce.Do(func() {
+		cardTestPANs = make(map[string]struct{})
+		for _, line := range strings.Split(resource.CardTestNumbers, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				cardTestPANs[line] = struct{}{}
+			}
+		}
+	})
+	_, ok := cardTestPANs[checkDigitStrip(pan, " -")]
+	return ok
+}
+
+// CardCVCValidate checks that cvc has the length required by brand, e.g.
+// four digits for "amex".
+func CardCVCValidate(cvc, brand string) error {
+	b, ok := CardBrandTable().Lookup(brand)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrCardBrandUnknown, brand)
+	}
+	if len(cvc) != b.CVCLength || !checkDigitIsDigits(cvc) {
+		return fmt.Errorf("%w: want %d digits for %s", ErrCardCVC, b.CVCLength, b.Name)
+	}
+	return nil
+}
+
+// CardExpiryParse parses an expiry date in the forms MM/YY or MM/YYYY.
+func CardExpiryParse(s string) (month, year int, err error) {
+	m, y, ok := strings.Cut(strings.ReplaceAll(s, " ", ""), "/")
+	if !ok || len(m) != 2 || len(y) != 2 && len(y) != 4 || !checkDigitIsDigits(m) || !checkDigitIsDigits(y) {
+		return 0, 0, fmt.Errorf("%w: %q, want MM/YY", ErrCardExpiry, s)
+	}
+	month, _ = strconv.Atoi(m)
+	year, _ = strconv.Atoi(y)
+	if len(y) == 2 {
+		year += 2000
+	}
+	return month, year, nil
+}
+
+// CardExpiryValidate checks that a card expiring in month and year is
+// still valid: cards expire at the end of their expiry month. Two-digit
+// years are taken as 20YY.
+func CardExpiryValidate(month, year int, opts CardOptions) error {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	t := now()
+	if year < 100 {
+		year += 2000
+	}
+	if month < 1 || month > 12 {
+		return fmt.Errorf("%w: month %d", ErrCardExpiry, month)
+	}
+	if year > t.Year()+cardMaxExpiryYears {
+		return fmt.Errorf("%w: year %d too far in the future", ErrCardExpiry, year)
+	}
+	if year < t.Year() || year == t.Year() && time.Month(month) < t.Month() {
+		return fmt.Errorf("%w: %02d/%d", ErrCardExpired, month, year)
+	}
+	return nil
+}
+
+func cardContainsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+func cardRule(validate func(string) error) ValidationRule {
+	return func(value interface{}) error {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+		}
+		return validate(s)
+	}
+}
+
+func mustCardBrands(data string) *CardBrands {
+	t, err := NewCardBrands(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,224 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestCardNumberValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		pan  string
+		opts validate.CardOptions
+	}
+
+	type want struct {
+		brand string
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "visa", in: in{pan: "4539578763621486"}, want: want{brand: "visa"}},
+		{name: "grouped", in: in{pan: "4539 5787 6362 1486"}, want: want{brand: "visa"}},
+		{name: "hyphens", in: in{pan: "4539-5787-6362-1486"}, want: want{brand: "visa"}},
+		{name: "mastercard 2-series", in: in{pan: "2221000000000017"}, want: want{brand: "mastercard"}},
+		{name: "amex", in: in{pan: "370000000000002"}, want: want{brand: "amex"}},
+		// Co-branded UnionPay cards in Discover's IIN table are detected as
+		// discover on purpose.
+		{name: "discover within unionpay range", in: in{pan: "6221260000000000"}, want: want{brand: "discover"}},
+		{name: "unionpay", in: in{pan: "6200000000000013"}, want: want{brand: "unionpay"}},
+		{name: "mir", in: in{pan: "2200000000000004"}, want: want{brand: "mir"}},
+		{name: "elo within discover range", in: in{pan: "6500310000000005"}, want: want{brand: "elo"}},
+		{name: "rupay", in: in{pan: "5085000000000007"}, want: want{brand: "rupay"}},
+		{name: "rupay 60 series", in: in{pan: "6079840000000002"}, want: want{brand: "rupay"}},
+		{name: "rupay within discover range", in: in{pan: "6521500000000006"}, want: want{brand: "rupay"}},
+		{name: "discover after rupay range", in: in{pan: "6531500000000004"}, want: want{brand: "discover"}},
+		{name: "60 outside rupay ranges", in: in{pan: "6085010000000004"}, want: want{err: validate.ErrCardBrandUnknown}},
+		{name: "maestro short", in: in{pan: "503800000005"}, want: want{brand: "maestro"}},
+		{name: "jcb", in: in{pan: "3530111333300000"}, want: want{brand: "jcb"}},
+		{name: "test number allowed", in: in{pan: "4111111111111111"}, want: want{brand: "visa"}},
+		{name: "test number rejected", in: in{pan: "4111 1111 1111 1111", opts: validate.CardOptions{RejectTestNumbers: true}}, want: want{err: validate.ErrCardTestNumber}},
+		{name: "brand accepted", in: in{pan: "370000000000002", opts: validate.CardOptions{Brands: []string{"visa", "amex"}}}, want: want{brand: "amex"}},
+		{name: "brand not accepted", in: in{pan: "370000000000002", opts: validate.CardOptions{Brands: []string{"visa"}}}, want: want{err: validate.ErrCardBrand}},
+		{name: "mastercard length", in: in{pan: "510000000000011"}, want: want{err: validate.ErrCardLength}},
+		{name: "visa length", in: in{pan: "453957876362149"}, want: want{err: validate.ErrCardLength}},
+		{name: "luhn", in: in{pan: "4539578763621487"}, want: want{err: validate.ErrCardChecksum}},
+		{name: "unknown brand", in: in{pan: "9999999999999995"}, want: want{err: validate.ErrCardBrandUnknown}},
+		{name: "letters", in: in{pan: "4539a78763621486"}, want: want{err: validate.ErrCardNumber}},
+		{name: "leading space", in: in{pan: " 4539578763621486"}, want: want{err: validate.ErrCardNumber}},
+		{name: "empty", in: in{pan: ""}, want: want{err: validate.ErrCardNumber}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			brand, err := validate.CardNumberValidate(tt.in.pan, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("CardNumberValidate(%q) error = %v, want %v", tt.in.pan, err, tt.want.err)
+			}
+			if err == nil && brand.Name != tt.want.brand {
+				t.Errorf("CardNumberValidate(%q) brand = %s, want %s", tt.in.pan, brand.Name, tt.want.brand)
+			}
+			if err != nil && !errors.Is(err, validate.ErrCardInvalid) {
+				t.Errorf("CardNumberValidate(%q) error = %v, want %v", tt.in.pan, err, validate.ErrCardInvalid)
+			}
+		})
+	}
+}
+
+func TestCardCVCValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cvc   string
+		brand string
+		want  error
+	}{
+		{cvc: "123", brand: "visa", want: nil},
+		{cvc: "1234", brand: "visa", want: validate.ErrCardCVC},
+		{cvc: "1234", brand: "amex", want: nil},
+		{cvc: "123", brand: "amex", want: validate.ErrCardCVC},
+		{cvc: "12a", brand: "visa", want: validate.ErrCardCVC},
+		{cvc: "123", brand: "bogus", want: validate.ErrCardBrandUnknown},
+	}
+
+	for _, tt := range tests {
+		err := validate.CardCVCValidate(tt.cvc, tt.brand)
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("CardCVCValidate(%q, %q) = %v, want %v", tt.cvc, tt.brand, err, tt.want)
+		}
+	}
+}
+
+func TestCardExpiryValidate(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2023, time.September, 30, 23, 59, 0, 0, time.UTC) }
+	opts := validate.CardOptions{Now: now}
+
+	tests := []struct {
+		name  string
+		month int
+		year  int
+		want  error
+	}{
+		{name: "current month", month: 9, year: 2023, want: nil},
+		{name: "two-digit year", month: 10, year: 23, want: nil},
+		{name: "previous month", month: 8, year: 2023, want: validate.ErrCardExpired},
+		{name: "previous year", month: 12, year: 2022, want: validate.ErrCardExpired},
+		{name: "month zero", month: 0, year: 2024, want: validate.ErrCardExpiry},
+		{name: "month 13", month: 13, year: 2024, want: validate.ErrCardExpiry},
+		{name: "far future", month: 1, year: 2050, want: validate.ErrCardExpiry},
+	}
+
+	for _, tt := range tests {
+		err := validate.CardExpiryValidate(tt.month, tt.year, opts)
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: CardExpiryValidate(%d, %d) = %v, want %v", tt.name, tt.month, tt.year, err, tt.want)
+		}
+	}
+}
+
+func TestCardExpiryParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s     string
+		month int
+		year  int
+		err   bool
+	}{
+		{s: "09/25", month: 9, year: 2025},
+		{s: "09 / 2025", month: 9, year: 2025},
+		{s: "9/25", err: true},
+		{s: "09-25", err: true},
+		{s: "09/225", err: true},
+	}
+
+	for _, tt := range tests {
+		month, year, err := validate.CardExpiryParse(tt.s)
+		if (err != nil) != tt.err || month != tt.month || year != tt.year {
+			t.Errorf("CardExpiryParse(%q) = %d, %d, %v, want %d, %d, error %v", tt.s, month, year, err, tt.month, tt.year, tt.err)
+		}
+	}
+}
+
+func TestCardBrandTable(t *testing.T) {
+	defer validate.CardSetBrandTable(validate.CardBrandTable())
+
+	err := validate.CardLoadBrandTable(strings.NewReader("# private label\nstore 9999 16 3\nvisa 4 16 3\n"))
+	if err != nil {
+		t.Fatalf("CardLoadBrandTable() error = %v", err)
+	}
+	if brand, err := validate.CardNumberValidate("9999999999999995", validate.CardOptions{}); err != nil || brand.Name != "store" {
+		t.Errorf("CardNumberValidate() = %v, %v, want store", brand, err)
+	}
+	if _, err := validate.CardNumberValidate("370000000000002", validate.CardOptions{}); !errors.Is(err, validate.ErrCardBrandUnknown) {
+		t.Errorf("CardNumberValidate(amex) error = %v, want %v", err, validate.ErrCardBrandUnknown)
+	}
+
+	for _, data := range []string{
+		"visa 4 16",
+		"visa 40-5 16 3",
+		"visa 4 7 3",
+		"visa 4 16 5",
+	} {
+		if _, err := validate.NewCardBrands(strings.NewReader(data)); err == nil {
+			t.Errorf("NewCardBrands(%q) error = nil", data)
+		}
+	}
+}
+
+func TestCardRules(t *testing.T) {
+	t.Parallel()
+
+	type checkout struct {
+		Number string `validate:"card=no_test,card_brand=visa|mastercard"`
+		CVC    string `validate:"cvc"`
+		Expiry string `validate:"card_expiry"`
+	}
+
+	expiry := time.Now().AddDate(1, 0, 0).Format("01/06")
+	if err := validate.ValidateStruct(checkout{Number: "4539578763621486", CVC: "123", Expiry: expiry}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	err := validate.ValidateStruct(checkout{Number: "4111111111111111", CVC: "12", Expiry: "01/20"})
+	for _, want := range []error{validate.ErrCardTestNumber, validate.ErrCardCVC, validate.ErrCardExpired} {
+		if !errors.Is(err, want) {
+			t.Errorf("ValidateStruct() error = %v, want %v", err, want)
+		}
+	}
+}
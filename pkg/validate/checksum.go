@@ -0,0 +1,410 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrCheckDigitFormat   = errors.New("invalid format")
+	ErrCheckDigitMismatch = errors.New("check digit mismatch")
+)
+
+// CheckDigitError reports an identifier that is malformed or fails its
+// checksum. Err wraps ErrCheckDigitFormat or ErrCheckDigitMismatch.
+type CheckDigitError struct {
+	Kind  string
+	Input string
+	Err   error
+}
+
+func (e *CheckDigitError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %v", e.Kind, e.Input, e.Err)
+}
+
+func (e *CheckDigitError) Unwrap() error {
+	return e.Err
+}
+
+func init() {
+	RegisterRule("luhn", checkDigitRuleFactory(LuhnValidate))
+	RegisterRule("iban", checkDigitRuleFactory(func(s string) error {
+		_, err := IBANParse(s)
+		return err
+	}))
+	RegisterRule("isbn", checkDigitRuleFactory(ISBNValidate))
+	RegisterRule("isbn10", checkDigitRuleFactory(ISBN10Validate))
+	RegisterRule("isbn13", checkDigitRuleFactory(ISBN13Validate))
+	RegisterRule("ean8", checkDigitRuleFactory(EAN8Validate))
+	RegisterRule("ean13", checkDigitRuleFactory(EAN13Validate))
+	RegisterRule("upca", checkDigitRuleFactory(UPCAValidate))
+	RegisterRule("upce", checkDigitRuleFactory(UPCEValidate))
+	RegisterRule("gtin", checkDigitRuleFactory(GTINValidate))
+	RegisterRule("gtin14", checkDigitRuleFactory(GTIN14Validate))
+	RegisterRule("issn", checkDigitRuleFactory(ISSNValidate))
+	RegisterRule("isin", checkDigitRuleFactory(ISINValidate))
+}
+
+// LuhnValidate checks a string of at least two digits against the Luhn
+// (mod 10) algorithm used by card numbers and IMEIs.
+func LuhnValidate(s string) error {
+	if len(s) < 2 || !checkDigitIsDigits(s) {
+		return checkDigitFormatErr("Luhn number", s, "want at least 2 digits")
+	}
+	if luhnSum(s)%10 != 0 {
+		return checkDigitMismatchErr("Luhn number", s)
+	}
+	return nil
+}
+
+func LuhnIsValid(s string) bool {
+	return LuhnValidate(s) == nil
+}
+
+// LuhnCheckDigit returns the digit that completes payload to a valid
+// Luhn number.
+func LuhnCheckDigit(payload string) (byte, error) {
+	if payload == "" || !checkDigitIsDigits(payload) {
+		return 0, checkDigitFormatErr("Luhn payload", payload, "want digits")
+	}
+	return byte('0' + (10-luhnSum(payload+"0")%10)%10), nil
+}
+
+// ISBNValidate checks an ISBN-10 or ISBN-13. Hyphens and spaces between
+// the parts are ignored.
+func ISBNValidate(s string) error {
+	if len(checkDigitStrip(s, "- ")) == 10 {
+		return ISBN10Validate(s)
+	}
+	return ISBN13Validate(s)
+}
+
+func ISBNIsValid(s string) bool {
+	return ISBNValidate(s) == nil
+}
+
+func ISBN10Validate(s string) error {
+	isbn := strings.ToUpper(checkDigitStrip(s, "- "))
+	if len(isbn) != 10 || !checkDigitIsDigits(isbn[:9]) || !checkDigitIsDigits(isbn[9:]) && isbn[9] != 'X' {
+		return checkDigitFormatErr("ISBN-10", s, "want 9 digits and a digit or X")
+	}
+	if isbn10CheckDigit(isbn[:9]) != isbn[9] {
+		return checkDigitMismatchErr("ISBN-10", s)
+	}
+	return nil
+}
+
+func ISBN10IsValid(s string) bool {
+	return ISBN10Validate(s) == nil
+}
+
+func ISBN13Validate(s string) error {
+	isbn := checkDigitStrip(s, "- ")
+	if len(isbn) != 13 || !checkDigitIsDigits(isbn) || !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return checkDigitFormatErr("ISBN-13", s, "want 13 digits starting with 978 or 979")
+	}
+	if !gtinIsValid(isbn) {
+		return checkDigitMismatchErr("ISBN-13", s)
+	}
+	return nil
+}
+
+func ISBN13IsValid(s string) bool {
+	return ISBN13Validate(s) == nil
+}
+
+// ISBN10To13 converts a valid ISBN-10 to its ISBN-13 without separators.
+func ISBN10To13(s string) (string, error) {
+	if err := ISBN10Validate(s); err != nil {
+		return "", err
+	}
+	isbn := "978" + checkDigitStrip(s, "- ")[:9]
+	return isbn + string(gtinCheckDigit(isbn)), nil
+}
+
+// ISBN13To10 converts a valid ISBN-13 to its ISBN-10 without separators.
+// Only ISBNs starting with 978 have an ISBN-10.
+func ISBN13To10(s string) (string, error) {
+	if err := ISBN13Validate(s); err != nil {
+		return "", err
+	}
+	isbn := checkDigitStrip(s, "- ")
+	if !strings.HasPrefix(isbn, "978") {
+		return "", checkDigitFormatErr("ISBN-13", s, "979 ISBNs have no ISBN-10")
+	}
+	return isbn[3:12] + string(isbn10CheckDigit(isbn[3:12])), nil
+}
+
+func EAN8Validate(s string) error {
+	return gtinValidate("EAN-8", s, 8)
+}
+
+func EAN8IsValid(s string) bool {
+	return EAN8Validate(s) == nil
+}
+
+func EAN13Validate(s string) error {
+	return gtinValidate("EAN-13", s, 13)
+}
+
+func EAN13IsValid(s string) bool {
+	return EAN13Validate(s) == nil
+}
+
+func UPCAValidate(s string) error {
+	return gtinValidate("UPC-A", s, 12)
+}
+
+func UPCAIsValid(s string) bool {
+	return UPCAValidate(s) == nil
+}
+
+// UPCEValidate checks an 8-digit zero-suppressed UPC-E code by expanding
+// it to UPC-A.
+func UPCEValidate(s string) error {
+	_, err := UPCEToUPCA(s)
+	return err
+}
+
+func UPCEIsValid(s string) bool {
+	return UPCEValidate(s) == nil
+}
+
+// UPCEToUPCA expands an 8-digit UPC-E code with number system 0 or 1 to
+// UPC-A.
+func UPCEToUPCA(s string) (string, error) {
+	if len(s) != 8 || !checkDigitIsDigits(s) || s[0] != '0' && s[0] != '1' {
+		return "", checkDigitFormatErr("UPC-E", s, "want 8 digits with number system 0 or 1")
+	}
+	d := s[1:7]
+	var body string
+	switch d[5] {
+	case '0', '1', '2':
+		body = d[0:2] + d[5:6] + "0000" + d[2:5]
+	case '3':
+		body = d[0:3] + "00000" + d[3:5]
+	case '4':
+		body = d[0:4] + "00000" + d[4:5]
+	default:
+		body = d[0:5] + "0000" + d[5:6]
+	}
+	upca := s[:1] + body + s[7:]
+	if !gtinIsValid(upca) {
+		return "", checkDigitMismatchErr("UPC-E", s)
+	}
+	return upca, nil
+}
+
+// GTINValidate checks a GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) or
+// GTIN-14.
+func GTINValidate(s string) error {
+	switch len(s) {
+	case 8, 12, 13, 14:
+		return gtinValidate("GTIN", s, len(s))
+	}
+	return checkDigitFormatErr("GTIN", s, "want 8, 12, 13 or 14 digits")
+}
+
+func GTINIsValid(s string) bool {
+	return GTINValidate(s) == nil
+}
+
+func GTIN14Validate(s string) error {
+	return gtinValidate("GTIN-14", s, 14)
+}
+
+func GTIN14IsValid(s string) bool {
+	return GTIN14Validate(s) == nil
+}
+
+// ISSNValidate checks an ISSN such as "0378-5955", with or without the
+// hyphen.
+func ISSNValidate(s string) error {
+	issn := s
+	if len(s) == 9 && s[4] == '-' {
+		issn = s[:4] + s[5:]
+	}
+	issn = strings.ToUpper(issn)
+	if len(issn) != 8 || !checkDigitIsDigits(issn[:7]) || !checkDigitIsDigits(issn[7:]) && issn[7] != 'X' {
+		return checkDigitFormatErr("ISSN", s, "want NNNN-NNNC")
+	}
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += int(issn[i]-'0') * (8 - i)
+	}
+	check := byte('0' + (11-sum%11)%11)
+	if check == '0'+10 {
+		check = 'X'
+	}
+	if check != issn[7] {
+		return checkDigitMismatchErr("ISSN", s)
+	}
+	return nil
+}
+
+func ISSNIsValid(s string) bool {
+	return ISSNValidate(s) == nil
+}
+
+// ISINValidate checks an ISIN: a two-letter country code, a nine
+// character national security identifier and a Luhn check digit over the
+// letters converted to numbers (A=10 ... Z=35).
+func ISINValidate(s string) error {
+	if len(s) != 12 || !checkDigitIsUpper(s[:2]) || !checkDigitIsAlnum(s[2:11]) || !checkDigitIsDigits(s[11:]) {
+		return checkDigitFormatErr("ISIN", s, "want 2 letters, 9 letters or digits and a digit")
+	}
+	var digits strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'Z' {
+			fmt.Fprintf(&digits, "%d", c-'A'+10)
+		} else {
+			digits.WriteByte(c)
+		}
+	}
+	if luhnSum(digits.String())%10 != 0 {
+		return checkDigitMismatchErr("ISIN", s)
+	}
+	return nil
+}
+
+func ISINIsValid(s string) bool {
+	return ISINValidate(s) == nil
+}
+
+// luhnSum returns the Luhn sum of the digits s, doubling every second
+// digit from the right.
+func luhnSum(s string) int {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if (len(s)-1-i)%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum
+}
+
+func isbn10CheckDigit(payload string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(payload[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
+
+func gtinValidate(kind, s string, length int) error {
+	if len(s) != length || !checkDigitIsDigits(s) {
+		return checkDigitFormatErr(kind, s, fmt.Sprintf("want %d digits", length))
+	}
+	if !gtinIsValid(s) {
+		return checkDigitMismatchErr(kind, s)
+	}
+	return nil
+}
+
+// gtinIsValid checks the GS1 mod 10 check digit of s, which is the last
+// digit.
+func gtinIsValid(s string) bool {
+	return gtinCheckDigit(s[:len(s)-1]) == s[len(s)-1]
+}
+
+// gtinCheckDigit weights the payload digits 3, 1, 3, ... from the right.
+func gtinCheckDigit(payload string) byte {
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func checkDigitStrip(s, separators string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(separators, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func checkDigitIsDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func checkDigitIsUpper(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func checkDigitIsAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !checkDigitIsDigits(s[i:i+1]) && !checkDigitIsUpper(s[i:i+1]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func checkDigitFormatErr(kind, s, reason string) error {
+	return &CheckDigitError{Kind: kind, Input: s, Err: fmt.Errorf("%w: %s", ErrCheckDigitFormat, reason)}
+}
+
+func checkDigitMismatchErr(kind, s string) error {
+	return &CheckDigitError{Kind: kind, Input: s, Err: ErrCheckDigitMismatch}
+}
+
+// checkDigitRuleFactory registers a validator returning a
+// *CheckDigitError, so that struct validation reports which check failed.
+func checkDigitRuleFactory(validate func(string) error) RuleFactory {
+	return func(string) (ValidationRule, error) {
+		return func(value interface{}) error {
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+			}
+			return validate(s)
+		}, nil
+	}
+}
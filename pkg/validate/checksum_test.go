@@ -0,0 +1,160 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestCheckDigitValidators(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		fn func(string) error
+		s  string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "luhn", in: in{fn: validate.LuhnValidate, s: "79927398713"}, want: want{err: nil}},
+		{name: "luhn mismatch", in: in{fn: validate.LuhnValidate, s: "79927398710"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "luhn letters", in: in{fn: validate.LuhnValidate, s: "7992739871a"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "luhn single digit", in: in{fn: validate.LuhnValidate, s: "0"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "isbn10", in: in{fn: validate.ISBN10Validate, s: "0-306-40615-2"}, want: want{err: nil}},
+		{name: "isbn10 X", in: in{fn: validate.ISBN10Validate, s: "0-8044-2957-X"}, want: want{err: nil}},
+		{name: "isbn10 lowercase x", in: in{fn: validate.ISBN10Validate, s: "080442957x"}, want: want{err: nil}},
+		{name: "isbn10 mismatch", in: in{fn: validate.ISBN10Validate, s: "0-306-40615-3"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "isbn10 X in body", in: in{fn: validate.ISBN10Validate, s: "0X06406152"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "isbn13", in: in{fn: validate.ISBN13Validate, s: "978-0-306-40615-7"}, want: want{err: nil}},
+		{name: "isbn13 mismatch", in: in{fn: validate.ISBN13Validate, s: "978-0-306-40615-6"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "isbn13 prefix", in: in{fn: validate.ISBN13Validate, s: "4006381333931"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "isbn either", in: in{fn: validate.ISBNValidate, s: "0306406152"}, want: want{err: nil}},
+		{name: "ean8", in: in{fn: validate.EAN8Validate, s: "96385074"}, want: want{err: nil}},
+		{name: "ean8 mismatch", in: in{fn: validate.EAN8Validate, s: "96385075"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "ean13", in: in{fn: validate.EAN13Validate, s: "4006381333931"}, want: want{err: nil}},
+		{name: "ean13 short", in: in{fn: validate.EAN13Validate, s: "400638133393"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "upca", in: in{fn: validate.UPCAValidate, s: "036000291452"}, want: want{err: nil}},
+		{name: "upca mismatch", in: in{fn: validate.UPCAValidate, s: "036000291453"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "upce", in: in{fn: validate.UPCEValidate, s: "01234565"}, want: want{err: nil}},
+		{name: "upce number system", in: in{fn: validate.UPCEValidate, s: "21234565"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "upce mismatch", in: in{fn: validate.UPCEValidate, s: "01234564"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "gtin14", in: in{fn: validate.GTIN14Validate, s: "10012345678902"}, want: want{err: nil}},
+		{name: "gtin any length", in: in{fn: validate.GTINValidate, s: "036000291452"}, want: want{err: nil}},
+		{name: "gtin length", in: in{fn: validate.GTINValidate, s: "0360002914"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "issn", in: in{fn: validate.ISSNValidate, s: "0378-5955"}, want: want{err: nil}},
+		{name: "issn X", in: in{fn: validate.ISSNValidate, s: "2434561X"}, want: want{err: nil}},
+		{name: "issn mismatch", in: in{fn: validate.ISSNValidate, s: "0378-5954"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "issn hyphen position", in: in{fn: validate.ISSNValidate, s: "037-85955"}, want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "isin", in: in{fn: validate.ISINValidate, s: "US0378331005"}, want: want{err: nil}},
+		{name: "isin letters", in: in{fn: validate.ISINValidate, s: "DE000BAY0017"}, want: want{err: nil}},
+		{name: "isin mismatch", in: in{fn: validate.ISINValidate, s: "US0378331006"}, want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "isin lowercase", in: in{fn: validate.ISINValidate, s: "us0378331005"}, want: want{err: validate.ErrCheckDigitFormat}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.in.fn(tt.in.s)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("%s(%q) = %v, want %v", tt.name, tt.in.s, err, tt.want.err)
+			}
+			var cerr *validate.CheckDigitError
+			if err != nil && !errors.As(err, &cerr) {
+				t.Errorf("%s(%q) error %T, want *CheckDigitError", tt.name, tt.in.s, err)
+			}
+		})
+	}
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	t.Parallel()
+
+	if d, err := validate.LuhnCheckDigit("7992739871"); err != nil || d != '3' {
+		t.Errorf("LuhnCheckDigit(%q) = %q, %v, want '3'", "7992739871", d, err)
+	}
+	if _, err := validate.LuhnCheckDigit(""); !errors.Is(err, validate.ErrCheckDigitFormat) {
+		t.Errorf("LuhnCheckDigit(%q) error = %v, want %v", "", err, validate.ErrCheckDigitFormat)
+	}
+}
+
+func TestISBNConversion(t *testing.T) {
+	t.Parallel()
+
+	if got, err := validate.ISBN10To13("0-306-40615-2"); err != nil || got != "9780306406157" {
+		t.Errorf("ISBN10To13() = %q, %v, want %q", got, err, "9780306406157")
+	}
+	if got, err := validate.ISBN13To10("978-0-8044-2957-3"); err != nil || got != "080442957X" {
+		t.Errorf("ISBN13To10() = %q, %v, want %q", got, err, "080442957X")
+	}
+	if _, err := validate.ISBN13To10("979-10-90636-07-1"); !errors.Is(err, validate.ErrCheckDigitFormat) {
+		t.Errorf("ISBN13To10(979) error = %v, want %v", err, validate.ErrCheckDigitFormat)
+	}
+	if got, err := validate.UPCEToUPCA("01200003"); err != nil || got != "012000000003" {
+		t.Errorf("UPCEToUPCA() = %q, %v, want %q", got, err, "012000000003")
+	}
+}
+
+func TestCheckDigitRules(t *testing.T) {
+	t.Parallel()
+
+	type product struct {
+		Account string `validate:"iban"`
+		Book    string `validate:"isbn"`
+		Barcode string `validate:"gtin"`
+		Journal string `validate:"issn"`
+		Bond    string `validate:"isin"`
+	}
+
+	if err := validate.ValidateStruct(product{
+		Account: "CH9300762011623852957",
+		Book:    "9780306406157",
+		Barcode: "4006381333931",
+		Journal: "0378-5955",
+		Bond:    "GB0002634946",
+	}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+
+	err := validate.ValidateStruct(product{
+		Account: "CH9300762011623852958",
+		Book:    "9780306406157",
+		Barcode: "400638133393x",
+		Journal: "0378-5955",
+		Bond:    "GB0002634946",
+	})
+	if !errors.Is(err, validate.ErrCheckDigitMismatch) || !errors.Is(err, validate.ErrCheckDigitFormat) {
+		t.Errorf("ValidateStruct() error = %v, want mismatch and format errors", err)
+	}
+}
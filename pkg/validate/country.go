@@ -0,0 +1,113 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+var ErrCountryUnknown = errors.New("unknown country code")
+
+var (
+	countryOnce  sync.Once
+	countryTable map[string]*Country
+)
+
+// Country is an ISO 3166-1 country. Numeric is empty for user-assigned
+// codes such as XK.
+type Country struct {
+	Alpha2  string
+	Alpha3  string
+	Numeric string
+	Name    string
+}
+
+func init() {
+	RegisterRule("country", stringRuleFactory(CountryIsValid, ErrCountryUnknown))
+	RegisterRule("country_alpha3", stringRuleFactory(func(code string) bool {
+		c, ok := CountryLookup(code)
+		return ok && c.Alpha3 == code
+	}, ErrCountryUnknown))
+}
+
+// CountryIsValid reports whether code is an upper-case ISO 3166-1
+// alpha-2 country code.
+func CountryIsValid(code string) bool {
+	c, ok := CountryLookup(code)
+	return ok && c.Alpha2 == code
+}
+
+// CountryLookup returns the country with the upper-case alpha-2, alpha-3
+// or numeric code.
+func CountryLookup(code string) (*Country, bool) {
+	c, ok := countries()[code]
+	return c, ok
+}
+
+func countries() map[string]*Country {
+	countryOnce.Do(func() {
+		table, err := parseCountries(resource.ISO3166)
+		if err != nil {
+			panic(err)
+		}
+		countryTable = table
+	})
+	return countryTable
+}
+
+// parseCountries parses lines of the form "DE DEU 276 Germany", indexing
+// each country by all of its codes. A numeric code of "---" marks
+// user-assigned codes.
+func parseCountries(data string) (map[string]*Country, error) {
+	table := make(map[string]*Country)
+	for n, line := range strings.Split(data, "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) != 4 || len(fields[0]) != 2 || len(fields[1]) != 3 || len(fields[2]) != 3 {
+			return nil, fmt.Errorf("iso3166 line %d: malformed entry", n+1)
+		}
+		c := &Country{Alpha2: fields[0], Alpha3: fields[1], Name: fields[3]}
+		codes := []string{c.Alpha2, c.Alpha3}
+		if fields[2] != "---" {
+			if !checkDigitIsDigits(fields[2]) {
+				return nil, fmt.Errorf("iso3166 line %d: invalid numeric code %q", n+1, fields[2])
+			}
+			c.Numeric = fields[2]
+			codes = append(codes, c.Numeric)
+		}
+		for _, code := range codes {
+			if _, ok := table[code]; ok {
+				return nil, fmt.Errorf("iso3166 line %d: duplicate code %s", n+1, code)
+			}
+			table[code] = c
+		}
+	}
+	return table, nil
+}
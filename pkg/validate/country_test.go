@@ -0,0 +1,80 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestCountryLookup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code   string
+		alpha2 string
+		valid  bool
+	}{
+		{code: "DE", alpha2: "DE", valid: true},
+		{code: "DEU", alpha2: "DE"},
+		{code: "276", alpha2: "DE"},
+		{code: "GB", alpha2: "GB", valid: true},
+		{code: "XK", alpha2: "XK", valid: true},
+		{code: "de"},
+		{code: "UK"},
+		{code: "ZZ"},
+		{code: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			c, ok := validate.CountryLookup(tt.code)
+			if ok != (tt.alpha2 != "") || ok && c.Alpha2 != tt.alpha2 {
+				t.Errorf("CountryLookup(%q) = %v, %v, want %s", tt.code, c, ok, tt.alpha2)
+			}
+			if got := validate.CountryIsValid(tt.code); got != tt.valid {
+				t.Errorf("CountryIsValid(%q) = %v, want %v", tt.code, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestCountryRules(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Country string `validate:"country"`
+		Alpha3  string `validate:"country_alpha3"`
+	}
+
+	if err := validate.ValidateStruct(address{Country: "FR", Alpha3: "FRA"}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	if err := validate.ValidateStruct(address{Country: "FRA", Alpha3: "FR"}); err == nil {
+		t.Error("ValidateStruct() error = nil")
+	}
+}
@@ -0,0 +1,297 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+// Decimal is satisfied by the representations of exact decimal values
+// accepted by the Decimal validators.
+type Decimal interface {
+	string | *big.Rat | *big.Float
+}
+
+var (
+	ErrDecimalInvalid     = errors.New("invalid decimal number")
+	ErrDecimalPrecision   = errors.New("decimal has too many digits")
+	ErrDecimalScale       = errors.New("decimal has too many fractional digits")
+	ErrCurrencyUnknown    = errors.New("unknown currency code")
+	ErrCurrencyMinorUnits = fmt.Errorf("%w for the currency", ErrDecimalScale)
+)
+
+var (
+	currencyOnce           sync.Once
+	currencyMinorUnitTable map[string]int
+)
+
+func init() {
+	RegisterRule("decimal", func(param string) (ValidationRule, error) {
+		p, s, ok := strings.Cut(param, "|")
+		precision, err1 := strconv.Atoi(p)
+		scale, err2 := strconv.Atoi(s)
+		if !ok || err1 != nil || err2 != nil || precision < 0 || scale < 0 || precision > 0 && scale > precision {
+			return nil, fmt.Errorf("%w: %q, want precision|scale", ErrNumericParam, param)
+		}
+		return func(value interface{}) error {
+			return decimalValidate(value, precision, scale)
+		}, nil
+	})
+	RegisterRule("decimal_min", decimalBoundRuleFactory(false))
+	RegisterRule("decimal_max", decimalBoundRuleFactory(true))
+	RegisterRule("currency", stringRuleFactory(CurrencyIsValid, ErrCurrencyUnknown))
+	RegisterRule("currency_amount", func(param string) (ValidationRule, error) {
+		if !CurrencyIsValid(param) {
+			return nil, fmt.Errorf("%w: %q", ErrCurrencyUnknown, param)
+		}
+		return func(value interface{}) error {
+			return currencyAmountValidate(value, param)
+		}, nil
+	})
+}
+
+// DecimalParse parses a plain decimal number such as "-1234.50" exactly.
+// Exponents, separators and surrounding spaces are not accepted.
+func DecimalParse(s string) (*big.Rat, error) {
+	r, _, _, err := decimalAnalyze(s)
+	return r, err
+}
+
+// DecimalPrecisionScale returns the number of significant digits and of
+// fractional digits of v, ignoring leading and trailing zeros: "0012.50"
+// has precision 3 and scale 1. A *big.Float is taken at the shortest
+// decimal that represents it at its precision, so a float64 0.1 has
+// scale 1. A *big.Rat must be a finite decimal, unlike 1/3.
+func DecimalPrecisionScale[T Decimal](v T) (precision, scale int, err error) {
+	_, precision, scale, err = decimalAnalyze(v)
+	return precision, scale, err
+}
+
+// DecimalValidate checks if v fits a SQL DECIMAL(precision, scale): at
+// most scale fractional digits and precision-scale integer digits. A
+// precision of 0 only limits the scale.
+func DecimalValidate[T Decimal](v T, precision, scale int) error {
+	return decimalValidate(v, precision, scale)
+}
+
+func DecimalIsValid[T Decimal](v T, precision, scale int) bool {
+	return decimalValidate(v, precision, scale) == nil
+}
+
+// DecimalRangeValidate checks lo <= v <= hi with exact arithmetic. A nil
+// bound is not checked.
+func DecimalRangeValidate[T Decimal](v T, lo, hi *big.Rat) error {
+	r, _, _, err := decimalAnalyze(v)
+	if err != nil {
+		return err
+	}
+	if lo != nil && r.Cmp(lo) < 0 {
+		return fmt.Errorf("%w: %s, minimum is %s", ErrNumericTooSmall, r.RatString(), lo.RatString())
+	}
+	if hi != nil && r.Cmp(hi) > 0 {
+		return fmt.Errorf("%w: %s, maximum is %s", ErrNumericTooLarge, r.RatString(), hi.RatString())
+	}
+	return nil
+}
+
+// CurrencyIsValid checks if code is an ISO 4217 alphabetic currency code,
+// e.g. "EUR".
+func CurrencyIsValid(code string) bool {
+	_, ok := currencyMinorUnits()[code]
+	return ok
+}
+
+// CurrencyMinorUnits returns the number of fractional digits of a currency:
+// 2 for EUR, 0 for JPY and 3 for KWD. It returns false for unknown codes
+// and for codes without a minor unit such as XAU.
+func CurrencyMinorUnits(code string) (int, bool) {
+	n, ok := currencyMinorUnits()[code]
+	return n, ok && n >= 0
+}
+
+// CurrencyAmountValidate checks that amount has no more fractional digits
+// than the minor units of currency allow.
+func CurrencyAmountValidate[T Decimal](amount T, currency string) error {
+	return currencyAmountValidate(amount, currency)
+}
+
+func CurrencyAmountIsValid[T Decimal](amount T, currency string) bool {
+	return currencyAmountValidate(amount, currency) == nil
+}
+
+func decimalValidate(v interface{}, precision, scale int) error {
+	_, p, s, err := decimalAnalyze(v)
+	if err != nil {
+		return err
+	}
+	if s > scale {
+		return fmt.Errorf("%w: %d, maximum is %d", ErrDecimalScale, s, scale)
+	}
+	if precision > 0 && p-s > precision-scale {
+		return fmt.Errorf("%w: %d integer digits, maximum is %d", ErrDecimalPrecision, p-s, precision-scale)
+	}
+	return nil
+}
+
+func currencyAmountValidate(v interface{}, currency string) error {
+	units, ok := currencyMinorUnits()[currency]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrCurrencyUnknown, currency)
+	}
+	_, _, s, err := decimalAnalyze(v)
+	if err != nil {
+		return err
+	}
+	if units >= 0 && s > units {
+		return fmt.Errorf("%w: %s allows %d, got %d", ErrCurrencyMinorUnits, currency, units, s)
+	}
+	return nil
+}
+
+// decimalAnalyze converts v to a big.Rat and returns its precision and
+// scale as described for DecimalPrecisionScale.
+func decimalAnalyze(v interface{}) (*big.Rat, int, int, error) {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case *big.Rat:
+		if x == nil {
+			return nil, 0, 0, fmt.Errorf("%w: nil", ErrDecimalInvalid)
+		}
+		scale, ok := decimalRatScale(x)
+		if !ok {
+			return nil, 0, 0, fmt.Errorf("%w: %s is not a finite decimal", ErrDecimalInvalid, x.RatString())
+		}
+		s = x.FloatString(scale)
+	case *big.Float:
+		if x == nil || x.IsInf() {
+			return nil, 0, 0, fmt.Errorf("%w: %v", ErrDecimalInvalid, x)
+		}
+		s = x.Text('f', -1)
+	default:
+		return nil, 0, 0, fmt.Errorf("%w: %T, want string, *big.Rat or *big.Float", ErrUnsupportedType, v)
+	}
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	intPart, fracPart, hasPoint := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" || hasPoint && fracPart == "" ||
+		strings.Trim(intPart, "0123456789") != "" || strings.Trim(fracPart, "0123456789") != "" {
+		return nil, 0, 0, fmt.Errorf("%w: %q", ErrDecimalInvalid, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: %q", ErrDecimalInvalid, s)
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	fracPart = strings.TrimRight(fracPart, "0")
+	return r, len(intPart) + len(fracPart), len(fracPart), nil
+}
+
+// decimalRatScale returns the number of fractional digits of r, which is
+// finite if the denominator has no prime factors other than 2 and 5.
+func decimalRatScale(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	var twos, fives int
+	m := new(big.Int)
+	for {
+		if q, rem := new(big.Int).QuoRem(d, two, m); rem.Sign() == 0 {
+			d, twos = q, twos+1
+			continue
+		}
+		if q, rem := new(big.Int).QuoRem(d, five, m); rem.Sign() == 0 {
+			d, fives = q, fives+1
+			continue
+		}
+		break
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+func decimalBoundRuleFactory(upper bool) RuleFactory {
+	return func(param string) (ValidationRule, error) {
+		bound, err := DecimalParse(param)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrNumericParam, param)
+		}
+		return func(value interface{}) error {
+			r, _, _, err := decimalAnalyze(value)
+			if err != nil {
+				return err
+			}
+			if upper {
+				return DecimalRangeValidate(r, nil, bound)
+			}
+			return DecimalRangeValidate(r, bound, nil)
+		}, nil
+	}
+}
+
+func currencyMinorUnits() map[string]int {
+	currencyOnce.Do(func() {
+		table, err := parseCurrencies(resource.ISO4217)
+		if err != nil {
+			panic(err)
+		}
+		currencyMinorUnitTable = table
+	})
+	return currencyMinorUnitTable
+}
+
+// parseCurrencies parses lines of the form "EUR 978 2 Euro". Minor units
+// of "N.A." are stored as -1.
+func parseCurrencies(data string) (map[string]int, error) {
+	table := make(map[string]int)
+	for n, line := range strings.Split(data, "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || len(fields[0]) != 3 {
+			return nil, fmt.Errorf("iso4217 line %d: malformed entry", n+1)
+		}
+		units := -1
+		if fields[2] != "N.A." {
+			var err error
+			if units, err = strconv.Atoi(fields[2]); err != nil {
+				return nil, fmt.Errorf("iso4217 line %d: invalid minor units %q", n+1, fields[2])
+			}
+		}
+		table[fields[0]] = units
+	}
+	return table, nil
+}
@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestDecimalValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s         string
+		precision int
+		scale     int
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "fits", in: in{s: "9999999999.99", precision: 12, scale: 2}, want: want{err: nil}},
+		{name: "negative", in: in{s: "-9999999999.99", precision: 12, scale: 2}, want: want{err: nil}},
+		{name: "too many integer digits", in: in{s: "10000000000.00", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalPrecision}},
+		{name: "too many fractional digits", in: in{s: "1.005", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalScale}},
+		{name: "trailing zeros", in: in{s: "1.5000", precision: 12, scale: 2}, want: want{err: nil}},
+		{name: "leading zeros", in: in{s: "000000000001.5", precision: 3, scale: 2}, want: want{err: nil}},
+		{name: "integer", in: in{s: "42", precision: 2, scale: 0}, want: want{err: nil}},
+		{name: "scale only", in: in{s: "123456789012345678901234567890.25", precision: 0, scale: 2}, want: want{err: nil}},
+		{name: "fraction only", in: in{s: ".25", precision: 2, scale: 2}, want: want{err: nil}},
+		{name: "trailing point", in: in{s: "1.", precision: 2, scale: 2}, want: want{err: validate.ErrDecimalInvalid}},
+		{name: "exponent", in: in{s: "1e3", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalInvalid}},
+		{name: "fraction syntax", in: in{s: "1/2", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalInvalid}},
+		{name: "empty", in: in{s: "", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalInvalid}},
+		{name: "sign only", in: in{s: "-", precision: 12, scale: 2}, want: want{err: validate.ErrDecimalInvalid}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate.DecimalValidate(tt.in.s, tt.in.precision, tt.in.scale)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("DecimalValidate(%q, %d, %d) = %v, want %v", tt.in.s, tt.in.precision, tt.in.scale, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestDecimalPrecisionScale(t *testing.T) {
+	t.Parallel()
+
+	if p, s, err := validate.DecimalPrecisionScale("0012.50"); p != 3 || s != 1 || err != nil {
+		t.Errorf("DecimalPrecisionScale(%q) = %d, %d, %v, want 3, 1, nil", "0012.50", p, s, err)
+	}
+	if p, s, err := validate.DecimalPrecisionScale(big.NewRat(1, 8)); p != 3 || s != 3 || err != nil {
+		t.Errorf("DecimalPrecisionScale(1/8) = %d, %d, %v, want 3, 3, nil", p, s, err)
+	}
+	if _, _, err := validate.DecimalPrecisionScale(big.NewRat(1, 3)); !errors.Is(err, validate.ErrDecimalInvalid) {
+		t.Errorf("DecimalPrecisionScale(1/3) error = %v, want %v", err, validate.ErrDecimalInvalid)
+	}
+	if p, s, err := validate.DecimalPrecisionScale(big.NewFloat(0.1)); p != 1 || s != 1 || err != nil {
+		t.Errorf("DecimalPrecisionScale(0.1) = %d, %d, %v, want 1, 1, nil", p, s, err)
+	}
+	var rat *big.Rat
+	if _, _, err := validate.DecimalPrecisionScale(rat); !errors.Is(err, validate.ErrDecimalInvalid) {
+		t.Errorf("DecimalPrecisionScale(nil) error = %v, want %v", err, validate.ErrDecimalInvalid)
+	}
+}
+
+func TestDecimalRangeValidate(t *testing.T) {
+	t.Parallel()
+
+	lo, hi := big.NewRat(1, 100), big.NewRat(100000, 1)
+	tests := []struct {
+		s    string
+		want error
+	}{
+		{s: "0.01", want: nil},
+		{s: "0.009999999999999999999", want: validate.ErrNumericTooSmall},
+		{s: "100000.00", want: nil},
+		{s: "100000.000000000000000001", want: validate.ErrNumericTooLarge},
+	}
+
+	for _, tt := range tests {
+		err := validate.DecimalRangeValidate(tt.s, lo, hi)
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("DecimalRangeValidate(%q) = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
+
+func TestCurrencyAmountValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		amount   string
+		currency string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "EUR", in: in{amount: "12.34", currency: "EUR"}, want: want{err: nil}},
+		{name: "EUR sub-cent", in: in{amount: "12.345", currency: "EUR"}, want: want{err: validate.ErrCurrencyMinorUnits}},
+		{name: "JPY", in: in{amount: "1200", currency: "JPY"}, want: want{err: nil}},
+		{name: "JPY fraction", in: in{amount: "1200.5", currency: "JPY"}, want: want{err: validate.ErrDecimalScale}},
+		{name: "JPY zero fraction", in: in{amount: "1200.00", currency: "JPY"}, want: want{err: nil}},
+		{name: "KWD", in: in{amount: "1.125", currency: "KWD"}, want: want{err: nil}},
+		{name: "KWD too precise", in: in{amount: "1.1255", currency: "KWD"}, want: want{err: validate.ErrCurrencyMinorUnits}},
+		{name: "no minor unit", in: in{amount: "1.123456", currency: "XAU"}, want: want{err: nil}},
+		{name: "unknown", in: in{amount: "1", currency: "ABC"}, want: want{err: validate.ErrCurrencyUnknown}},
+		{name: "lowercase code", in: in{amount: "1", currency: "eur"}, want: want{err: validate.ErrCurrencyUnknown}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate.CurrencyAmountValidate(tt.in.amount, tt.in.currency)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("CurrencyAmountValidate(%q, %q) = %v, want %v", tt.in.amount, tt.in.currency, err, tt.want.err)
+			}
+		})
+	}
+
+	if n, ok := validate.CurrencyMinorUnits("XAU"); ok {
+		t.Errorf("CurrencyMinorUnits(%q) = %d, true, want false", "XAU", n)
+	}
+	if n, ok := validate.CurrencyMinorUnits("CLF"); !ok || n != 4 {
+		t.Errorf("CurrencyMinorUnits(%q) = %d, %v, want 4, true", "CLF", n, ok)
+	}
+}
+
+func TestDecimalRules(t *testing.T) {
+	t.Parallel()
+
+	type payment struct {
+		Amount   string   `validate:"decimal=12|2,decimal_min=0.01,currency_amount=EUR"`
+		Fee      *big.Rat `validate:"decimal=5|2,decimal_max=100"`
+		Currency string   `validate:"currency"`
+	}
+
+	if err := validate.ValidateStruct(payment{Amount: "19.99", Fee: big.NewRat(5, 4), Currency: "USD"}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	err := validate.ValidateStruct(payment{Amount: "0.001", Fee: big.NewRat(20001, 200), Currency: "XYZ"})
+	for _, want := range []error{validate.ErrDecimalScale, validate.ErrNumericTooSmall, validate.ErrNumericTooLarge, validate.ErrCurrencyUnknown} {
+		if !errors.Is(err, want) {
+			t.Errorf("ValidateStruct() error = %v, want %v", err, want)
+		}
+	}
+	for _, tag := range []string{"decimal=2", "decimal=2|3", "decimal_min=1e3", "currency_amount=XYZ"} {
+		if err := validate.NewValidate().AddNamedRule(tag); err == nil {
+			t.Errorf("AddNamedRule(%q) error = nil", tag)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+	"golang.org/x/net/idna"
+)
+
+const pslPrivateSectionMarker = "===BEGIN PRIVATE DOMAINS==="
+
+var (
+	ErrDomainInvalid      = errors.New("invalid domain")
+	ErrDomainPublicSuffix = errors.New("domain is a public suffix")
+)
+
+var (
+	domainPSL     atomic.Value // *PublicSuffixList
+	domainPSLOnce sync.Once
+)
+
+func init() {
+	RegisterRule("domain_registrable", stringRuleFactory(DomainIsRegistrable, ErrDomainPublicSuffix))
+}
+
+// PublicSuffixList is an immutable set of rules in the format of
+// https://publicsuffix.org/list/. Rules are stored in their A-label form.
+// The bool value of each rule records whether it comes from the ICANN
+// section of the list rather than the PRIVATE one.
+type PublicSuffixList struct {
+	rules      map[string]bool
+	wildcards  map[string]bool
+	exceptions map[string]bool
+}
+
+func NewPublicSuffixList(r io.Reader) (*PublicSuffixList, error) {
+	l := &PublicSuffixList{
+		rules:      make(map[string]bool),
+		wildcards:  make(map[string]bool),
+		exceptions: make(map[string]bool),
+	}
+
+	icann := true
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "//") {
+			if strings.Contains(line, pslPrivateSectionMarker) {
+				icann = false
+			}
+			continue
+		}
+		// Only the first whitespace-delimited field of a line is a rule.
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		if line == "" {
+			continue
+		}
+
+		set := l.rules
+		switch {
+		case strings.HasPrefix(line, "!"):
+			set = l.exceptions
+			line = line[1:]
+		case strings.HasPrefix(line, "*."):
+			set = l.wildcards
+			line = line[2:]
+		}
+		rule, err := idna.Punycode.ToASCII(strings.ToLower(line))
+		if err != nil || rule == "" || strings.Contains(rule, "*") {
+			return nil, fmt.Errorf("line %d: invalid rule %q", n, line)
+		}
+		set[rule] = icann
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *PublicSuffixList) Len() int {
+	return len(l.rules) + len(l.wildcards) + len(l.exceptions)
+}
+
+// PublicSuffix returns the public suffix of the A-label domain and whether
+// it is managed by ICANN. Domains not covered by any rule fall back to the
+// implicit "*" rule, so their last label is the public suffix.
+func (l *PublicSuffixList) PublicSuffix(domain string) (suffix string, icann bool) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+
+	// Walk the suffixes from the shortest to the longest, keeping the
+	// longest matching rule. An exception rule always wins and makes its
+	// parent domain the public suffix.
+	start := strings.LastIndexByte(domain, '.') + 1
+	suffix = domain[start:]
+	for {
+		s := domain[start:]
+		if ok, found := l.exceptions[s]; found {
+			return s[strings.IndexByte(s, '.')+1:], ok
+		}
+		if ok, found := l.rules[s]; found {
+			suffix, icann = s, ok
+		}
+		if start == 0 {
+			return suffix, icann
+		}
+
+		parent := strings.LastIndexByte(domain[:start-1], '.') + 1
+		if ok, found := l.wildcards[s]; found {
+			suffix, icann = domain[parent:], ok
+		}
+		start = parent
+	}
+}
+
+// hasTLD reports whether a rule of the list covers the top-level domain
+// tld, as opposed to the implicit "*" rule.
+func (l *PublicSuffixList) hasTLD(tld string) bool {
+	_, rule := l.rules[tld]
+	_, wildcard := l.wildcards[tld]
+	return rule || wildcard
+}
+
+// EffectiveTLDPlusOne returns the registrable domain of the A-label
+// domain: its public suffix plus one more label.
+func (l *PublicSuffixList) EffectiveTLDPlusOne(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	suffix, _ := l.PublicSuffix(domain)
+	if len(domain) <= len(suffix) {
+		return "", fmt.Errorf("%w: %q", ErrDomainPublicSuffix, domain)
+	}
+	rest := domain[:len(domain)-len(suffix)-1]
+	return rest[strings.LastIndexByte(rest, '.')+1:] + "." + suffix, nil
+}
+
+// DomainEffectiveTLDPlusOne validates s as a hostname and returns its
+// registrable domain in A-label form, e.g. "example.co.uk" for
+// "www.example.co.uk". Internationalized names may be given as U-labels.
+func DomainEffectiveTLDPlusOne(s string) (string, error) {
+	domain, err := domainToASCII(s)
+	if err != nil {
+		return "", err
+	}
+	return DomainPublicSuffixList().EffectiveTLDPlusOne(domain)
+}
+
+// DomainIsRegistrable checks if s is itself a registrable domain, that is
+// exactly one label below a public suffix, like "example.com" but neither
+// "com" nor "www.example.com".
+func DomainIsRegistrable(s string) bool {
+	domain, err := domainToASCII(s)
+	if err != nil {
+		return false
+	}
+	etld1, err := DomainPublicSuffixList().EffectiveTLDPlusOne(domain)
+	return err == nil && etld1 == domain
+}
+
+// DomainPublicSuffixList returns the list used by the Domain functions.
+// The embedded snapshot is parsed on first use.
+func DomainPublicSuffixList() *PublicSuffixList {
+	domainPSLOnce.Do(func() {
+		domainPSL.CompareAndSwap(nil, mustPublicSuffixList(resource.PublicSuffixList))
+	})
+	return domainPSL.Load().(*PublicSuffixList)
+}
+
+// DomainSetPublicSuffixList atomically replaces the list used by the
+// Domain functions.
+func DomainSetPublicSuffixList(l *PublicSuffixList) {
+	domainPSL.Store(l)
+}
+
+func DomainLoadPublicSuffixList(r io.Reader) error {
+	l, err := NewPublicSuffixList(r)
+	if err != nil {
+		return err
+	}
+	DomainSetPublicSuffixList(l)
+	return nil
+}
+
+func DomainLoadPublicSuffixListFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := DomainLoadPublicSuffixList(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func domainToASCII(s string) (string, error) {
+	name := strings.TrimSuffix(s, ".")
+	if !isASCII(name) {
+		ascii, err := emailIDNA.ToASCII(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrDomainInvalid, err)
+		}
+		name = ascii
+	}
+	name = strings.ToLower(name)
+	if err := HostnameValidate(name, HostnameOptions{}); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDomainInvalid, err)
+	}
+	return name, nil
+}
+
+func mustPublicSuffixList(data string) *PublicSuffixList {
+	l, err := NewPublicSuffixList(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
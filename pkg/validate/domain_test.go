@@ -0,0 +1,218 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestPublicSuffixList_PublicSuffix(t *testing.T) {
+	t.Parallel()
+
+	const list = `// ===BEGIN ICANN DOMAINS===
+com
+uk
+co.uk
+// ck : https://en.wikipedia.org/wiki/.ck
+*.ck
+!www.ck
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+// ===END PRIVATE DOMAINS===
+`
+	psl, err := validate.NewPublicSuffixList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("NewPublicSuffixList() error = %v", err)
+	}
+
+	type in struct {
+		domain string
+	}
+
+	type want struct {
+		suffix string
+		icann  bool
+		etld1  string
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "tld",
+			in:   in{domain: "www.example.com"},
+			want: want{suffix: "com", icann: true, etld1: "example.com"},
+		},
+		{
+			name: "second level",
+			in:   in{domain: "www.example.co.uk"},
+			want: want{suffix: "co.uk", icann: true, etld1: "example.co.uk"},
+		},
+		{
+			name: "suffix itself",
+			in:   in{domain: "co.uk"},
+			want: want{suffix: "co.uk", icann: true, etld1: ""},
+		},
+		{
+			name: "wildcard",
+			in:   in{domain: "www.example.foo.ck"},
+			want: want{suffix: "foo.ck", icann: true, etld1: "example.foo.ck"},
+		},
+		{
+			name: "exception",
+			in:   in{domain: "www.ck"},
+			want: want{suffix: "ck", icann: true, etld1: "www.ck"},
+		},
+		{
+			name: "private",
+			in:   in{domain: "user.github.io"},
+			want: want{suffix: "github.io", icann: false, etld1: "user.github.io"},
+		},
+		{
+			name: "unlisted tld",
+			in:   in{domain: "host.example.internal"},
+			want: want{suffix: "internal", icann: false, etld1: "example.internal"},
+		},
+		{
+			name: "trailing dot and case",
+			in:   in{domain: "WWW.Example.COM."},
+			want: want{suffix: "com", icann: true, etld1: "example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suffix, icann := psl.PublicSuffix(tt.in.domain)
+			if suffix != tt.want.suffix || icann != tt.want.icann {
+				t.Errorf("PublicSuffix(%q) = %q, %v, want %q, %v", tt.in.domain, suffix, icann, tt.want.suffix, tt.want.icann)
+			}
+			etld1, err := psl.EffectiveTLDPlusOne(tt.in.domain)
+			if etld1 != tt.want.etld1 || (err != nil) != (tt.want.etld1 == "") {
+				t.Errorf("EffectiveTLDPlusOne(%q) = %q, %v, want %q", tt.in.domain, etld1, err, tt.want.etld1)
+			}
+		})
+	}
+}
+
+func TestDomainEffectiveTLDPlusOne(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		etld1       string
+		err         error
+		registrable bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "registrable",
+			in:   in{s: "example.com"},
+			want: want{etld1: "example.com", registrable: true},
+		},
+		{
+			name: "subdomain",
+			in:   in{s: "app.tenant.example.co.uk"},
+			want: want{etld1: "example.co.uk", registrable: false},
+		},
+		{
+			name: "public suffix",
+			in:   in{s: "co.uk"},
+			want: want{err: validate.ErrDomainPublicSuffix},
+		},
+		{
+			name: "private suffix",
+			in:   in{s: "tenant.github.io"},
+			want: want{etld1: "tenant.github.io", registrable: true},
+		},
+		{
+			name: "u-label",
+			in:   in{s: "www.例子.广告"},
+			want: want{etld1: "xn--fsqu00a.xn--4rr70v", registrable: false},
+		},
+		{
+			name: "invalid hostname",
+			in:   in{s: "-bad.example.com"},
+			want: want{err: validate.ErrDomainInvalid},
+		},
+		{
+			name: "ip address",
+			in:   in{s: "127.0.0.1"},
+			want: want{err: validate.ErrDomainInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.DomainEffectiveTLDPlusOne(tt.in.s)
+			if got != tt.want.etld1 || tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("DomainEffectiveTLDPlusOne(%q) = %q, %v, want %q, %v", tt.in.s, got, err, tt.want.etld1, tt.want.err)
+			}
+			if got := validate.DomainIsRegistrable(tt.in.s); got != tt.want.registrable {
+				t.Errorf("DomainIsRegistrable(%q) = %v, want %v", tt.in.s, got, tt.want.registrable)
+			}
+		})
+	}
+}
+
+// TestDomainLoadPublicSuffixList swaps the process-wide list, so it must
+// not run in parallel with the tests that read it.
+func TestDomainLoadPublicSuffixList(t *testing.T) {
+	original := validate.DomainPublicSuffixList()
+	defer validate.DomainSetPublicSuffixList(original)
+
+	if original.Len() == 0 {
+		t.Fatalf("embedded list is empty")
+	}
+
+	path := filepath.Join(t.TempDir(), "public_suffix_list.dat")
+	if err := os.WriteFile(path, []byte("com\nexample.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := validate.DomainLoadPublicSuffixListFile(path); err != nil {
+		t.Fatalf("DomainLoadPublicSuffixListFile() error = %v", err)
+	}
+	if validate.DomainIsRegistrable("example.com") || !validate.DomainIsRegistrable("tenant.example.com") {
+		t.Errorf("override list not in effect")
+	}
+
+	if err := validate.DomainLoadPublicSuffixList(strings.NewReader("*.*.com\n")); err == nil {
+		t.Errorf("DomainLoadPublicSuffixList() error = nil, want error")
+	}
+}
@@ -23,12 +23,227 @@
 package validate
 
 import (
-	"regexp"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/progxeno/validate/internal/pkg/resource"
+	"golang.org/x/net/idna"
 )
 
+const (
+	emailMaxLength    = 254
+	emailMaxLocalPart = 64
+)
+
+var (
+	ErrEmailSyntax    = errors.New("invalid email address")
+	ErrEmailLocalPart = errors.New("invalid email local part")
+	ErrEmailDomain    = errors.New("invalid email domain")
+	ErrEmailTooLong   = errors.New("email address too long")
+)
+
+// emailIDNA validates domains per IDNA 2008 (UTS #46 non-transitional
+// processing), including the bidi rule and the CONTEXTJ rules.
+var emailIDNA = idna.New(
+	idna.MapForLookup(),
+	idna.Transitional(false),
+	idna.BidiRule(),
+	idna.CheckHyphens(true),
+	idna.CheckJoiners(true),
+	idna.VerifyDNSLength(true),
+)
+
+type EmailAddress struct {
+	LocalPart string
+	// Domain is the domain in its Unicode (U-label) form.
+	Domain string
+	// ASCIIDomain is the domain in its Punycode (A-label) form.
+	ASCIIDomain string
+}
+
+func (a *EmailAddress) String() string {
+	return a.LocalPart + "@" + a.Domain
+}
+
+func (a *EmailAddress) ASCII() string {
+	return a.LocalPart + "@" + a.ASCIIDomain
+}
+
+// RequiresSMTPUTF8 reports whether the local part contains non-ASCII
+// characters, in which case the address can only be delivered via an
+// SMTPUTF8 (RFC 6531) capable server.
+func (a *EmailAddress) RequiresSMTPUTF8() bool {
+	return !isASCII(a.LocalPart)
+}
+
 func EmailIsValid(email string) bool {
-	match, _ := regexp.MatchString(resource.RegexEmailValidation, email)
-	return match
+	_, err := EmailParse(email)
+	return err == nil
+}
+
+func EmailParse(email string) (*EmailAddress, error) {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return nil, ErrEmailSyntax
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if err := emailValidateLocalPart(local); err != nil {
+		return nil, err
+	}
+
+	asciiDomain, unicodeDomain, err := emailValidateDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(local)+1+len(asciiDomain) > emailMaxLength {
+		return nil, ErrEmailTooLong
+	}
+
+	return &EmailAddress{
+		LocalPart:   local,
+		Domain:      unicodeDomain,
+		ASCIIDomain: asciiDomain,
+	}, nil
+}
+
+func emailValidateLocalPart(local string) error {
+	if len(local) > emailMaxLocalPart {
+		return fmt.Errorf("%w: longer than %d octets", ErrEmailLocalPart, emailMaxLocalPart)
+	}
+	if !utf8.ValidString(local) {
+		return fmt.Errorf("%w: not valid UTF-8", ErrEmailLocalPart)
+	}
+	if strings.HasPrefix(local, `"`) {
+		return emailValidateQuotedString(local)
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("%w: empty dot-atom segment", ErrEmailLocalPart)
+		}
+		for _, c := range atom {
+			if !emailIsAtext(c) {
+				return fmt.Errorf("%w: character %q not allowed", ErrEmailLocalPart, c)
+			}
+		}
+	}
+	return nil
+}
+
+func emailValidateQuotedString(local string) error {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return fmt.Errorf("%w: unterminated quoted string", ErrEmailLocalPart)
+	}
+
+	content := local[1 : len(local)-1]
+	escaped := false
+	for _, c := range content {
+		switch {
+		case escaped:
+			if c < ' ' || c > '~' {
+				return fmt.Errorf("%w: invalid quoted pair", ErrEmailLocalPart)
+			}
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			return fmt.Errorf("%w: unescaped quote", ErrEmailLocalPart)
+		case c >= ' ' && c <= '~':
+		case c >= utf8.RuneSelf && !unicode.IsControl(c):
+		default:
+			return fmt.Errorf("%w: character %q not allowed", ErrEmailLocalPart, c)
+		}
+	}
+	if escaped {
+		return fmt.Errorf("%w: unterminated quoted pair", ErrEmailLocalPart)
+	}
+	return nil
+}
+
+// emailIsAtext reports whether c is an atext character as extended by
+// RFC 6531 to include any non-ASCII UTF-8 character.
+func emailIsAtext(c rune) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	case strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", c):
+		return true
+	case c >= utf8.RuneSelf:
+		return !unicode.IsControl(c) && !unicode.IsSpace(c)
+	}
+	return false
+}
+
+func emailValidateDomain(domain string) (string, string, error) {
+	asciiDomain, err := emailIDNA.ToASCII(domain)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrEmailDomain, err)
+	}
+
+	unicodeDomain, err := emailIDNA.ToUnicode(asciiDomain)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrEmailDomain, err)
+	}
+
+	// Both forms must describe the same domain.
+	if roundTrip, err := emailIDNA.ToASCII(unicodeDomain); err != nil || roundTrip != asciiDomain {
+		return "", "", fmt.Errorf("%w: A-label and U-label do not round trip", ErrEmailDomain)
+	}
+
+	for _, c := range unicodeDomain {
+		if idnaIsDisallowed(c) {
+			return "", "", fmt.Errorf("%w: code point %U disallowed by IDNA 2008", ErrEmailDomain, c)
+		}
+	}
+
+	labels := strings.Split(asciiDomain, ".")
+	if len(labels) < 2 {
+		return "", "", fmt.Errorf("%w: missing top-level domain", ErrEmailDomain)
+	}
+	if !emailIsTLD(labels[len(labels)-1]) {
+		return "", "", fmt.Errorf("%w: invalid top-level domain", ErrEmailDomain)
+	}
+
+	return asciiDomain, unicodeDomain, nil
+}
+
+func emailIsTLD(label string) bool {
+	if strings.HasPrefix(label, "xn--") {
+		return len(label) > len("xn--")
+	}
+	if len(label) < 2 {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		if label[i] < 'a' || label[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// idnaIsDisallowed reports whether c is one of the symbol and punctuation
+// code points that UTS #46 lookup accepts (NV8) but RFC 5892 disallows.
+func idnaIsDisallowed(c rune) bool {
+	switch c {
+	case '-', '.':
+		return false
+	case '\u00b7', '\u0375', '\u05f3', '\u05f4', '\u0f0b', '\u3007', '\u30fb':
+		// CONTEXTO and PVALID exceptions from RFC 5892, section 2.6.
+		return false
+	}
+	return unicode.In(c, unicode.S, unicode.P, unicode.Zs, unicode.Nl, unicode.No)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,311 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"strings"
+)
+
+const (
+	emailSuggestionMaxDistance = 2
+	// emailSuggestionMaxDomain is the longest domain, in octets, for which
+	// corrections are searched.
+	emailSuggestionMaxDomain = 253
+)
+
+// EmailProvider describes how a mail provider maps addresses to mailboxes.
+type EmailProvider struct {
+	// Domains served by the provider. The first domain is canonical and
+	// the others are aliases for the same mailboxes.
+	Domains []string
+	// IgnoreDots reports whether dots in the local part are insignificant.
+	IgnoreDots bool
+	// TagSeparator starts a sub-address tag that is stripped, e.g. "+".
+	TagSeparator string
+	// CaseInsensitive reports whether the local part is case-insensitive.
+	CaseInsensitive bool
+}
+
+var DefaultEmailProviders = []EmailProvider{
+	{Domains: []string{"gmail.com", "googlemail.com"}, IgnoreDots: true, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"outlook.com"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"hotmail.com"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"live.com"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"icloud.com", "me.com", "mac.com"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"proton.me", "protonmail.com", "protonmail.ch", "pm.me"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"fastmail.com"}, TagSeparator: "+", CaseInsensitive: true},
+	{Domains: []string{"yahoo.com"}, CaseInsensitive: true},
+	{Domains: []string{"yandex.ru", "yandex.com", "ya.ru"}, TagSeparator: "+", CaseInsensitive: true},
+}
+
+var DefaultEmailSuggestionDomains = []string{
+	"gmail.com", "googlemail.com", "yahoo.com", "yahoo.co.uk", "hotmail.com",
+	"hotmail.co.uk", "outlook.com", "live.com", "msn.com", "aol.com",
+	"icloud.com", "me.com", "mac.com", "proton.me", "protonmail.com",
+	"gmx.com", "gmx.de", "gmx.net", "web.de", "t-online.de", "yandex.ru",
+	"mail.ru", "comcast.net", "verizon.net", "att.net", "qq.com", "163.com",
+	"zoho.com", "fastmail.com", "orange.fr", "free.fr", "laposte.net",
+	"libero.it",
+}
+
+// DefaultEmailKnownDomains are provider domains that are never corrected
+// although they are close to a popular domain, e.g. "mail.com" and
+// "gmail.com".
+var DefaultEmailKnownDomains = []string{
+	"mail.com", "email.com", "ymail.com", "rocketmail.com", "aim.com",
+	"gmx.at", "gmx.ch", "gmx.fr", "gmx.us", "gmx.co.uk", "hotmail.de",
+	"hotmail.fr", "hotmail.it", "hotmail.es", "live.de", "live.fr",
+	"live.co.uk", "yahoo.de", "yahoo.fr", "yahoo.it", "yahoo.es", "mail.de",
+	"mac.com", "me.com", "inbox.ru", "list.ru", "bk.ru", "yandex.com",
+	"ya.ru", "pm.me", "proton.ch", "hey.com", "tuta.io", "tutanota.com",
+}
+
+var DefaultEmailSuggestionTLDs = []string{
+	"com", "net", "org", "edu", "gov", "info", "biz", "io", "co", "me",
+	"us", "uk", "co.uk", "de", "fr", "it", "es", "nl", "be", "ch", "at",
+	"se", "pl", "ru", "jp", "cn", "br", "au", "ca",
+}
+
+var (
+	defaultEmailCanonicalizer = NewEmailCanonicalizer(DefaultEmailProviders)
+	defaultEmailSuggester     = NewEmailSuggester(DefaultEmailSuggestionDomains, DefaultEmailSuggestionTLDs)
+)
+
+type EmailCanonicalizer struct {
+	providers map[string]*EmailProvider
+}
+
+func NewEmailCanonicalizer(providers []EmailProvider) *EmailCanonicalizer {
+	c := &EmailCanonicalizer{providers: make(map[string]*EmailProvider)}
+	for i := range providers {
+		p := &providers[i]
+		for _, d := range p.Domains {
+			c.providers[strings.ToLower(d)] = p
+		}
+	}
+	return c
+}
+
+// EmailCanonicalize returns the canonical form of email using the default
+// provider table, so that addresses delivered to the same mailbox compare
+// equal. Domains are always case-folded; local parts of unknown providers
+// are left untouched since they are case-sensitive per RFC 5321.
+func EmailCanonicalize(email string) (string, error) {
+	return defaultEmailCanonicalizer.Canonicalize(email)
+}
+
+func (c *EmailCanonicalizer) Canonicalize(email string) (string, error) {
+	addr, err := EmailParse(email)
+	if err != nil {
+		return "", err
+	}
+
+	local, domain := addr.LocalPart, addr.Domain
+	p, ok := c.providers[domain]
+	if !ok {
+		p, ok = c.providers[addr.ASCIIDomain]
+	}
+	if !ok {
+		return local + "@" + domain, nil
+	}
+
+	if p.TagSeparator != "" {
+		if i := strings.Index(local, p.TagSeparator); i > 0 {
+			local = local[:i]
+		}
+	}
+	if p.IgnoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	if p.CaseInsensitive {
+		local = strings.ToLower(local)
+	}
+	return local + "@" + strings.ToLower(p.Domains[0]), nil
+}
+
+// EmailSuggester proposes corrections from lists ordered by popularity;
+// ties in edit distance go to the earlier entry. Domains that are listed,
+// known or on a public suffix from the Public Suffix List keep that
+// suffix; only unknown suffixes are corrected.
+type EmailSuggester struct {
+	domains []string
+	tlds    []string
+	// KnownDomains are never corrected, in addition to the suggestion
+	// domains and the domains of DefaultEmailProviders. It defaults to
+	// DefaultEmailKnownDomains.
+	KnownDomains []string
+	// MaxDistance is the largest edit distance for which a correction is
+	// proposed.
+	MaxDistance int
+}
+
+func NewEmailSuggester(domains []string, tlds []string) *EmailSuggester {
+	s := &EmailSuggester{
+		KnownDomains: DefaultEmailKnownDomains,
+		MaxDistance:  emailSuggestionMaxDistance,
+	}
+	for _, d := range domains {
+		s.domains = append(s.domains, strings.ToLower(d))
+	}
+	for _, tld := range tlds {
+		s.tlds = append(s.tlds, strings.ToLower(tld))
+	}
+	return s
+}
+
+// EmailSuggest proposes a correction for a likely misspelled domain, e.g.
+// "user@gmial.con" yields "user@gmail.com". It reports false if no
+// correction is found.
+func EmailSuggest(email string) (string, bool) {
+	return defaultEmailSuggester.Suggest(email)
+}
+
+func (s *EmailSuggester) Suggest(email string) (string, bool) {
+	addr, err := EmailParse(email)
+	if err != nil || len(addr.ASCIIDomain) > emailSuggestionMaxDomain {
+		return "", false
+	}
+	local, domain := addr.LocalPart, strings.ToLower(addr.ASCIIDomain)
+	if s.isKnown(domain) {
+		return "", false
+	}
+
+	// A real public suffix such as ".cm" is never rewritten, so only
+	// domains on the same suffix are candidates.
+	psl := DomainPublicSuffixList()
+	suffix, _ := psl.PublicSuffix(domain)
+	candidates := s.domains
+	listed := psl.hasTLD(domain[strings.LastIndexByte(domain, '.')+1:])
+	if listed {
+		candidates = nil
+		for _, d := range s.domains {
+			if strings.HasSuffix(d, "."+suffix) {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+	if d, ok := emailClosest(domain, candidates, s.MaxDistance); ok {
+		return emailSuggestion(local, d)
+	}
+	if listed {
+		return "", false
+	}
+
+	// Fall back to correcting only the top-level domain, trying two
+	// labels first so that "co.uk"-style TLDs are respected.
+	labels := strings.Split(domain, ".")
+	for n := 2; n >= 1; n-- {
+		if len(labels) <= n {
+			continue
+		}
+		name, tld := strings.Join(labels[:len(labels)-n], "."), strings.Join(labels[len(labels)-n:], ".")
+		if containsString(s.tlds, tld) {
+			return "", false
+		}
+		if t, ok := emailClosest(tld, s.tlds, 1); ok && strings.Count(t, ".") == n-1 {
+			return emailSuggestion(local, name+"."+t)
+		}
+	}
+	return "", false
+}
+
+func (s *EmailSuggester) isKnown(domain string) bool {
+	if containsString(s.domains, domain) || containsString(s.KnownDomains, domain) {
+		return true
+	}
+	for _, p := range DefaultEmailProviders {
+		if containsString(p.Domains, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func emailSuggestion(local string, domain string) (string, bool) {
+	suggestion := local + "@" + domain
+	if !EmailIsValid(suggestion) {
+		return "", false
+	}
+	return suggestion, true
+}
+
+func emailClosest(s string, candidates []string, maxDistance int) (string, bool) {
+	best, bestDistance := "", maxDistance+1
+	for _, c := range candidates {
+		// The distance is at least the difference in length.
+		if len(s)-len(c) > maxDistance || len(c)-len(s) > maxDistance {
+			continue
+		}
+		if d := editDistance(s, c); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best, best != ""
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the Levenshtein distance extended with transpositions of adjacent
+// characters.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(n int, rest ...int) int {
+	for _, m := range rest {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
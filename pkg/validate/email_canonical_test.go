@@ -0,0 +1,315 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestEmailCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		email string
+	}
+
+	type want struct {
+		result string
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "gmail dots, tag and case",
+			in: in{
+				email: "John.Doe+x@GMail.com",
+			},
+			want: want{
+				result: "johndoe@gmail.com",
+				err:    nil,
+			},
+		},
+		{
+			name: "gmail alias domain",
+			in: in{
+				email: "johndoe@googlemail.com",
+			},
+			want: want{
+				result: "johndoe@gmail.com",
+				err:    nil,
+			},
+		},
+		{
+			name: "outlook keeps dots",
+			in: in{
+				email: "John.Doe+news@outlook.com",
+			},
+			want: want{
+				result: "john.doe@outlook.com",
+				err:    nil,
+			},
+		},
+		{
+			name: "unknown provider keeps local part",
+			in: in{
+				email: "John.Doe+x@Example.com",
+			},
+			want: want{
+				result: "John.Doe+x@example.com",
+				err:    nil,
+			},
+		},
+		{
+			name: "invalid email",
+			in: in{
+				email: "invalid-email",
+			},
+			want: want{
+				result: "",
+				err:    validate.ErrEmailSyntax,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.EmailCanonicalize(tt.in.email)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("EmailCanonicalize(%q) error = %v, want %v", tt.in.email, err, tt.want.err)
+			}
+			if got != tt.want.result {
+				t.Errorf("EmailCanonicalize(%q) = %q, want %q", tt.in.email, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestEmailCanonicalizerCustomProvider(t *testing.T) {
+	t.Parallel()
+
+	c := validate.NewEmailCanonicalizer([]validate.EmailProvider{
+		{Domains: []string{"example.com", "example.org"}, TagSeparator: "-"},
+	})
+	got, err := c.Canonicalize("Jane-news@example.org")
+	if err != nil {
+		t.Fatalf("Canonicalize() error = %v", err)
+	}
+	if want := "Jane@example.com"; got != want {
+		t.Errorf("Canonicalize() = %q, want %q", got, want)
+	}
+}
+
+func TestEmailSuggest(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		email string
+	}
+
+	type want struct {
+		result string
+		ok     bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "misspelled domain and tld",
+			in: in{
+				email: "user@gmial.con",
+			},
+			want: want{
+				result: "user@gmail.com",
+				ok:     true,
+			},
+		},
+		{
+			name: "misspelled domain",
+			in: in{
+				email: "user@gmial.com",
+			},
+			want: want{
+				result: "user@gmail.com",
+				ok:     true,
+			},
+		},
+		{
+			name: "misspelled two-label tld",
+			in: in{
+				email: "user@mycompany.co.ukk",
+			},
+			want: want{
+				result: "user@mycompany.co.uk",
+				ok:     true,
+			},
+		},
+		{
+			name: "invalid domain is not corrected",
+			in: in{
+				email: "user@hotmailcom",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "known provider close to gmail.com",
+			in: in{
+				email: "user@mail.com",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "known provider close to gmail.com by two edits",
+			in: in{
+				email: "user@ymail.com",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "known provider on another tld",
+			in: in{
+				email: "user@gmx.at",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "real cctld",
+			in: in{
+				email: "user@example.cm",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "real cctld of popular name",
+			in: in{
+				email: "user@gmail.cm",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "misspelled tld only",
+			in: in{
+				email: "user@mycompany.cmo",
+			},
+			want: want{
+				result: "user@mycompany.com",
+				ok:     true,
+			},
+		},
+		{
+			name: "misspelled tld of subdomain",
+			in: in{
+				email: "user@mail.mycompany.nte",
+			},
+			want: want{
+				result: "user@mail.mycompany.net",
+				ok:     true,
+			},
+		},
+		{
+			name: "known domain",
+			in: in{
+				email: "user@gmail.com",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "unknown domain with known tld",
+			in: in{
+				email: "user@mycompany.co.uk",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+		{
+			name: "invalid email",
+			in: in{
+				email: "gmial.com",
+			},
+			want: want{
+				result: "",
+				ok:     false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := validate.EmailSuggest(tt.in.email)
+			if got != tt.want.result || ok != tt.want.ok {
+				t.Errorf("EmailSuggest(%q) = %q, %v, want %q, %v", tt.in.email, got, ok, tt.want.result, tt.want.ok)
+			}
+		})
+	}
+}
+
+// TestEmailSuggestLongDomain checks that long inputs are rejected before
+// any edit distance is computed; they used to take quadratic time.
+func TestEmailSuggestLongDomain(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1000, 16000} {
+		email := "user@" + strings.Repeat("a.", n/2) + "con"
+		if got, ok := validate.EmailSuggest(email); ok {
+			t.Errorf("EmailSuggest(%d octet domain) = %q, want no suggestion", n, got)
+		}
+	}
+	// Long domains within the address length limit are still corrected.
+	email := "user@" + strings.Repeat("a.", 122) + "con"
+	if got, ok := validate.EmailSuggest(email); !ok || !strings.HasSuffix(got, ".com") {
+		t.Errorf("EmailSuggest(247 octet domain) = %q, %v, want .com suggestion", got, ok)
+	}
+}
@@ -0,0 +1,214 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	emailDeliverabilityTTL         = time.Hour
+	emailDeliverabilityNegativeTTL = 5 * time.Minute
+	emailDeliverabilityMaxEntries  = 10000
+)
+
+var (
+	// ErrEmailNoMailServer is a definitive result: the domain does not
+	// exist or publishes neither MX nor address records.
+	ErrEmailNoMailServer = errors.New("email domain has no mail server")
+	// ErrEmailNullMX reports a null MX record (RFC 7505), meaning the
+	// domain explicitly does not accept mail.
+	ErrEmailNullMX = fmt.Errorf("%w: null MX record", ErrEmailNoMailServer)
+	// ErrEmailLookupTimeout and ErrEmailLookupFailed are transient
+	// results; the address may be deliverable and the check can be retried.
+	ErrEmailLookupTimeout = errors.New("email domain lookup timed out")
+	ErrEmailLookupFailed  = errors.New("email domain lookup failed")
+)
+
+var defaultEmailDeliverabilityChecker = NewEmailDeliverabilityChecker(net.DefaultResolver)
+
+type EmailDeliverabilityChecker struct {
+	Resolver Resolver
+	// TTL is how long a deliverable result is cached.
+	TTL time.Duration
+	// NegativeTTL is how long a definitive "no mail server" result is
+	// cached. Transient failures are never cached.
+	NegativeTTL time.Duration
+	// MaxEntries bounds the number of cached domains. When the cache is
+	// full, expired entries are dropped first, then the entries closest to
+	// expiry. Zero or less disables caching.
+	MaxEntries int
+	// Now returns the current time and defaults to time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]emailDeliverabilityEntry
+}
+
+type emailDeliverabilityEntry struct {
+	err     error
+	expires time.Time
+}
+
+func NewEmailDeliverabilityChecker(resolver Resolver) *EmailDeliverabilityChecker {
+	return &EmailDeliverabilityChecker{
+		Resolver:    resolver,
+		TTL:         emailDeliverabilityTTL,
+		NegativeTTL: emailDeliverabilityNegativeTTL,
+		MaxEntries:  emailDeliverabilityMaxEntries,
+		Now:         time.Now,
+		cache:       make(map[string]emailDeliverabilityEntry),
+	}
+}
+
+// EmailDeliverable checks that the domain of addr can receive mail, using
+// the system resolver and a process-wide cache.
+func EmailDeliverable(ctx context.Context, addr string) error {
+	return defaultEmailDeliverabilityChecker.Deliverable(ctx, addr)
+}
+
+func (c *EmailDeliverabilityChecker) Deliverable(ctx context.Context, addr string) error {
+	email, err := EmailParse(addr)
+	if err != nil {
+		return err
+	}
+
+	domain := email.ASCIIDomain
+	if entry, ok := c.cached(domain); ok {
+		return entry.err
+	}
+
+	err = c.lookup(ctx, domain)
+	switch {
+	case err == nil:
+		c.store(domain, nil, c.TTL)
+	case errors.Is(err, ErrEmailNoMailServer):
+		c.store(domain, err, c.NegativeTTL)
+	}
+	return err
+}
+
+// lookup implements the MX resolution of RFC 5321, section 5.1: if the
+// domain has no MX records, its address records act as an implicit MX.
+func (c *EmailDeliverabilityChecker) lookup(ctx context.Context, domain string) error {
+	mxs, err := c.Resolver.LookupMX(ctx, domain)
+	if err != nil && !emailIsNotFound(err) {
+		return emailLookupError(err)
+	}
+
+	for _, mx := range mxs {
+		if mx.Host == "." || mx.Host == "" {
+			return ErrEmailNullMX
+		}
+	}
+	if len(mxs) > 0 {
+		return nil
+	}
+
+	addrs, err := c.Resolver.LookupIPAddr(ctx, domain)
+	if err != nil && !emailIsNotFound(err) {
+		return emailLookupError(err)
+	}
+	if len(addrs) == 0 {
+		return ErrEmailNoMailServer
+	}
+	return nil
+}
+
+func (c *EmailDeliverabilityChecker) cached(domain string) (emailDeliverabilityEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[domain]
+	if ok && !c.now().Before(entry.expires) {
+		delete(c.cache, domain)
+		return emailDeliverabilityEntry{}, false
+	}
+	return entry, ok
+}
+
+func (c *EmailDeliverabilityChecker) store(domain string, err error, ttl time.Duration) {
+	if ttl <= 0 || c.MaxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cache == nil {
+		c.cache = make(map[string]emailDeliverabilityEntry)
+	}
+	now := c.now()
+	if _, ok := c.cache[domain]; !ok && len(c.cache) >= c.MaxEntries {
+		c.evict(now)
+	}
+	c.cache[domain] = emailDeliverabilityEntry{err: err, expires: now.Add(ttl)}
+}
+
+// evict makes room for one entry in a full cache: it sweeps the expired
+// entries and then drops the entries that expire first. The caller must
+// hold c.mu.
+func (c *EmailDeliverabilityChecker) evict(now time.Time) {
+	for domain, entry := range c.cache {
+		if !now.Before(entry.expires) {
+			delete(c.cache, domain)
+		}
+	}
+	for len(c.cache) >= c.MaxEntries {
+		var (
+			oldest  string
+			expires time.Time
+		)
+		for domain, entry := range c.cache {
+			if oldest == "" || entry.expires.Before(expires) {
+				oldest, expires = domain, entry.expires
+			}
+		}
+		delete(c.cache, oldest)
+	}
+}
+
+func (c *EmailDeliverabilityChecker) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+func emailIsNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func emailLookupError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTimeout || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrEmailLookupTimeout, err)
+	}
+	return fmt.Errorf("%w: %v", ErrEmailLookupFailed, err)
+}
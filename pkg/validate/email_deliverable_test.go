@@ -0,0 +1,356 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+type stubResolver struct {
+	mx    map[string][]*net.MX
+	ips   map[string][]net.IPAddr
+	err   error
+	calls int
+}
+
+func (r *stubResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *stubResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if ips, ok := r.ips[host]; ok {
+		return ips, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailDeliverable(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		addr     string
+		resolver *stubResolver
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "mx record",
+			in: in{
+				addr: "user@example.com",
+				resolver: &stubResolver{
+					mx: map[string][]*net.MX{"example.com": {{Host: "mail.example.com.", Pref: 10}}},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "idn domain is looked up in punycode",
+			in: in{
+				addr: "josé@bücher.de",
+				resolver: &stubResolver{
+					mx: map[string][]*net.MX{"xn--bcher-kva.de": {{Host: "mx.xn--bcher-kva.de.", Pref: 10}}},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "address record fallback",
+			in: in{
+				addr: "user@example.com",
+				resolver: &stubResolver{
+					ips: map[string][]net.IPAddr{"example.com": {{IP: net.ParseIP("192.0.2.1")}}},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "null mx",
+			in: in{
+				addr: "user@example.com",
+				resolver: &stubResolver{
+					mx:  map[string][]*net.MX{"example.com": {{Host: ".", Pref: 0}}},
+					ips: map[string][]net.IPAddr{"example.com": {{IP: net.ParseIP("192.0.2.1")}}},
+				},
+			},
+			want: want{
+				err: validate.ErrEmailNullMX,
+			},
+		},
+		{
+			name: "no records",
+			in: in{
+				addr:     "user@example.com",
+				resolver: &stubResolver{},
+			},
+			want: want{
+				err: validate.ErrEmailNoMailServer,
+			},
+		},
+		{
+			name: "timeout",
+			in: in{
+				addr: "user@example.com",
+				resolver: &stubResolver{
+					err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true},
+				},
+			},
+			want: want{
+				err: validate.ErrEmailLookupTimeout,
+			},
+		},
+		{
+			name: "server failure",
+			in: in{
+				addr: "user@example.com",
+				resolver: &stubResolver{
+					err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true},
+				},
+			},
+			want: want{
+				err: validate.ErrEmailLookupFailed,
+			},
+		},
+		{
+			name: "invalid address",
+			in: in{
+				addr:     "invalid-email",
+				resolver: &stubResolver{},
+			},
+			want: want{
+				err: validate.ErrEmailSyntax,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validate.NewEmailDeliverabilityChecker(tt.in.resolver)
+			err := c.Deliverable(context.Background(), tt.in.addr)
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Deliverable(%q) error = %v, want %v", tt.in.addr, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestEmailDeliverableCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 7, 17, 12, 0, 0, 0, time.UTC)
+	resolver := &stubResolver{}
+	c := validate.NewEmailDeliverabilityChecker(resolver)
+	c.Now = func() time.Time { return now }
+
+	if err := c.Deliverable(context.Background(), "user@example.com"); !errors.Is(err, validate.ErrEmailNoMailServer) {
+		t.Fatalf("Deliverable() error = %v, want %v", err, validate.ErrEmailNoMailServer)
+	}
+	calls := resolver.calls
+
+	resolver.mx = map[string][]*net.MX{"example.com": {{Host: "mail.example.com.", Pref: 10}}}
+	if err := c.Deliverable(context.Background(), "other@example.com"); !errors.Is(err, validate.ErrEmailNoMailServer) {
+		t.Errorf("Deliverable() error = %v, want cached %v", err, validate.ErrEmailNoMailServer)
+	}
+	if resolver.calls != calls {
+		t.Errorf("resolver called %d times, want %d", resolver.calls, calls)
+	}
+
+	now = now.Add(c.NegativeTTL)
+	if err := c.Deliverable(context.Background(), "user@example.com"); err != nil {
+		t.Errorf("Deliverable() after expiry error = %v, want nil", err)
+	}
+
+	resolver.err = &net.DNSError{Err: "i/o timeout", Name: "example.org", IsTimeout: true}
+	for i := 0; i < 2; i++ {
+		if err := c.Deliverable(context.Background(), "user@example.org"); !errors.Is(err, validate.ErrEmailLookupTimeout) {
+			t.Errorf("Deliverable() error = %v, want %v", err, validate.ErrEmailLookupTimeout)
+		}
+	}
+	resolver.err = nil
+	resolver.mx["example.org"] = []*net.MX{{Host: "mail.example.org.", Pref: 10}}
+	if err := c.Deliverable(context.Background(), "user@example.org"); err != nil {
+		t.Errorf("Deliverable() after transient failure error = %v, want nil", err)
+	}
+}
+
+func TestEmailDeliverableCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 7, 17, 12, 0, 0, 0, time.UTC)
+	resolver := &stubResolver{mx: map[string][]*net.MX{
+		"a.example": {{Host: "mail.a.example.", Pref: 10}},
+		"b.example": {{Host: "mail.b.example.", Pref: 10}},
+		"c.example": {{Host: "mail.c.example.", Pref: 10}},
+	}}
+	c := validate.NewEmailDeliverabilityChecker(resolver)
+	c.MaxEntries = 2
+	c.Now = func() time.Time { return now }
+
+	lookups := func(domains ...string) int {
+		calls := resolver.calls
+		for _, domain := range domains {
+			if err := c.Deliverable(context.Background(), "user@"+domain); err != nil {
+				t.Fatalf("Deliverable(%s) error = %v", domain, err)
+			}
+		}
+		return resolver.calls - calls
+	}
+
+	lookups("a.example")
+	now = now.Add(time.Minute)
+	lookups("b.example")
+	if n := lookups("a.example", "b.example"); n != 0 {
+		t.Errorf("cached lookups called the resolver %d times, want 0", n)
+	}
+
+	// A third domain evicts a.example, which expires first.
+	lookups("c.example")
+	if n := lookups("b.example", "c.example"); n != 0 {
+		t.Errorf("cached lookups called the resolver %d times, want 0", n)
+	}
+	if n := lookups("a.example"); n == 0 {
+		t.Error("evicted a.example was served from the cache")
+	}
+
+	// Once every entry has expired, a new domain sweeps them all.
+	now = now.Add(c.TTL + time.Minute)
+	lookups("b.example")
+	if n := lookups("a.example"); n == 0 {
+		t.Error("expired a.example was served from the cache")
+	}
+}
+
+func TestEmailDeliverableDNSServer(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on udp: %v", err)
+	}
+	defer conn.Close()
+	go serveStubDNS(conn, map[string]string{
+		"example.com.": "mail.example.com.",
+		"example.net.": ".",
+	})
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+	c := validate.NewEmailDeliverabilityChecker(resolver)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.Deliverable(ctx, "user@example.com"); err != nil {
+		t.Errorf("Deliverable(example.com) error = %v, want nil", err)
+	}
+	if err := c.Deliverable(ctx, "user@example.net"); !errors.Is(err, validate.ErrEmailNullMX) {
+		t.Errorf("Deliverable(example.net) error = %v, want %v", err, validate.ErrEmailNullMX)
+	}
+	if err := c.Deliverable(ctx, "user@example.org"); !errors.Is(err, validate.ErrEmailNoMailServer) {
+		t.Errorf("Deliverable(example.org) error = %v, want %v", err, validate.ErrEmailNoMailServer)
+	}
+}
+
+// serveStubDNS answers MX queries from mx and reports NXDOMAIN for
+// everything else.
+func serveStubDNS(conn net.PacketConn, mx map[string]string) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err != nil {
+			continue
+		}
+		question, err := p.Question()
+		if err != nil {
+			continue
+		}
+
+		header.Response = true
+		header.Authoritative = true
+		header.RCode = dnsmessage.RCodeNameError
+		host, ok := mx[question.Name.String()]
+		if ok {
+			header.RCode = dnsmessage.RCodeSuccess
+		}
+
+		b := dnsmessage.NewBuilder(nil, header)
+		b.EnableCompression()
+		_ = b.StartQuestions()
+		_ = b.Question(question)
+		_ = b.StartAnswers()
+		if ok && question.Type == dnsmessage.TypeMX {
+			_ = b.MXResource(
+				dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 300},
+				dnsmessage.MXResource{Pref: 0, MX: dnsmessage.MustNewName(host)},
+			)
+		}
+		msg, err := b.Finish()
+		if err != nil {
+			continue
+		}
+		_, _ = conn.WriteTo(msg, addr)
+	}
+}
@@ -0,0 +1,272 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+const emailListVersionPrefix = "version:"
+
+var (
+	emailDisposableList atomic.Value // *EmailList
+	emailRoleList       atomic.Value // *EmailList
+)
+
+func init() {
+	emailDisposableList.Store(mustEmailList(resource.DisposableDomains))
+	emailRoleList.Store(mustEmailList(resource.RoleAccounts))
+}
+
+// EmailList is an immutable set of domains or local parts with optional
+// exceptions. Lists are read one entry per line; "#" starts a comment,
+// "# version: <v>" records the list version and a leading "!" marks an
+// exception.
+type EmailList struct {
+	version    string
+	entries    map[string]struct{}
+	exceptions map[string]struct{}
+	// domains and domainExceptions hold the entries as A-labels, so that
+	// U-label and A-label forms match each other.
+	domains          map[string]struct{}
+	domainExceptions map[string]struct{}
+}
+
+func NewEmailList(r io.Reader) (*EmailList, error) {
+	l := &EmailList{
+		entries:          make(map[string]struct{}),
+		exceptions:       make(map[string]struct{}),
+		domains:          make(map[string]struct{}),
+		domainExceptions: make(map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if strings.HasPrefix(comment, emailListVersionPrefix) && l.version == "" {
+				l.version = strings.TrimSpace(strings.TrimPrefix(comment, emailListVersionPrefix))
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		exception := strings.HasPrefix(line, "!")
+		if exception {
+			line = line[1:]
+		}
+		if err := l.add(line, exception); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *EmailList) Version() string {
+	return l.version
+}
+
+func (l *EmailList) Len() int {
+	return len(l.entries)
+}
+
+// WithExceptions returns a copy of the list with entries added to its
+// exceptions.
+func (l *EmailList) WithExceptions(entries ...string) (*EmailList, error) {
+	c := &EmailList{
+		version:          l.version,
+		entries:          l.entries,
+		exceptions:       make(map[string]struct{}, len(l.exceptions)+len(entries)),
+		domains:          l.domains,
+		domainExceptions: make(map[string]struct{}, len(l.domainExceptions)+len(entries)),
+	}
+	for e := range l.exceptions {
+		c.exceptions[e] = struct{}{}
+	}
+	for e := range l.domainExceptions {
+		c.domainExceptions[e] = struct{}{}
+	}
+	for _, e := range entries {
+		if err := c.add(e, true); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (l *EmailList) add(s string, exception bool) error {
+	entry, err := emailListEntry(s)
+	if err != nil {
+		return err
+	}
+	if exception {
+		l.exceptions[entry] = struct{}{}
+		l.domainExceptions[emailListDomain(entry)] = struct{}{}
+	} else {
+		l.entries[entry] = struct{}{}
+		l.domains[emailListDomain(entry)] = struct{}{}
+	}
+	return nil
+}
+
+// ContainsDomain reports whether domain or one of its parent domains is
+// listed. Domains and entries are compared as A-labels, so U-label and
+// A-label forms are interchangeable. The most specific match wins, so an
+// exception for a subdomain overrides a listed parent domain.
+func (l *EmailList) ContainsDomain(domain string) bool {
+	domain = emailListDomain(strings.TrimSuffix(strings.ToLower(domain), "."))
+	for {
+		if _, ok := l.domainExceptions[domain]; ok {
+			return false
+		}
+		if _, ok := l.domains[domain]; ok {
+			return true
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// ContainsLocalPart reports whether local, ignoring case and any "+tag"
+// suffix, is listed.
+func (l *EmailList) ContainsLocalPart(local string) bool {
+	local = strings.ToLower(local)
+	if i := strings.IndexByte(local, '+'); i > 0 {
+		local = local[:i]
+	}
+	if _, ok := l.exceptions[local]; ok {
+		return false
+	}
+	_, ok := l.entries[local]
+	return ok
+}
+
+func EmailIsDisposable(email string) bool {
+	addr, err := EmailParse(email)
+	return err == nil && EmailDisposableList().ContainsDomain(addr.ASCIIDomain)
+}
+
+func EmailIsRoleAccount(email string) bool {
+	addr, err := EmailParse(email)
+	return err == nil && EmailRoleAccountList().ContainsLocalPart(addr.LocalPart)
+}
+
+func EmailDisposableList() *EmailList {
+	return emailDisposableList.Load().(*EmailList)
+}
+
+// EmailSetDisposableList atomically replaces the list used by
+// EmailIsDisposable.
+func EmailSetDisposableList(l *EmailList) {
+	emailDisposableList.Store(l)
+}
+
+func EmailLoadDisposableList(r io.Reader) error {
+	l, err := NewEmailList(r)
+	if err != nil {
+		return err
+	}
+	EmailSetDisposableList(l)
+	return nil
+}
+
+func EmailLoadDisposableListFile(path string) error {
+	return emailLoadListFile(path, EmailLoadDisposableList)
+}
+
+func EmailRoleAccountList() *EmailList {
+	return emailRoleList.Load().(*EmailList)
+}
+
+// EmailSetRoleAccountList atomically replaces the list used by
+// EmailIsRoleAccount.
+func EmailSetRoleAccountList(l *EmailList) {
+	emailRoleList.Store(l)
+}
+
+func EmailLoadRoleAccountList(r io.Reader) error {
+	l, err := NewEmailList(r)
+	if err != nil {
+		return err
+	}
+	EmailSetRoleAccountList(l)
+	return nil
+}
+
+func EmailLoadRoleAccountListFile(path string) error {
+	return emailLoadListFile(path, EmailLoadRoleAccountList)
+}
+
+func emailLoadListFile(path string, load func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := load(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func emailListEntry(s string) (string, error) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	if s == "" || strings.ContainsAny(s, " \t@") {
+		return "", fmt.Errorf("invalid list entry %q", s)
+	}
+	return s, nil
+}
+
+// emailListDomain returns the A-label form of a domain, or s itself if it
+// is not a valid IDNA domain.
+func emailListDomain(s string) string {
+	if ascii, err := emailIDNA.ToASCII(s); err == nil {
+		return ascii
+	}
+	return s
+}
+
+func mustEmailList(data string) *EmailList {
+	l, err := NewEmailList(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
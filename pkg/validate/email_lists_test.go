@@ -0,0 +1,354 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestEmailIsDisposable(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		email string
+	}
+
+	type want struct {
+		result bool
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "disposable domain",
+			in: in{
+				email: "user@mailinator.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "subdomain of disposable domain",
+			in: in{
+				email: "user@inbox.Mailinator.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "regular domain",
+			in: in{
+				email: "user@example.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "invalid email",
+			in: in{
+				email: "mailinator.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validate.EmailIsDisposable(tt.in.email)
+			if got != tt.want.result {
+				t.Errorf("EmailIsDisposable(%q) = %v, want %v", tt.in.email, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestEmailIsRoleAccount(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		email string
+	}
+
+	type want struct {
+		result bool
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "role account",
+			in: in{
+				email: "admin@example.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "role account with tag and mixed case",
+			in: in{
+				email: "NoReply+billing@example.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "personal account",
+			in: in{
+				email: "john.doe@example.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validate.EmailIsRoleAccount(tt.in.email)
+			if got != tt.want.result {
+				t.Errorf("EmailIsRoleAccount(%q) = %v, want %v", tt.in.email, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestEmailListContainsDomain(t *testing.T) {
+	t.Parallel()
+
+	list, err := validate.NewEmailList(strings.NewReader(`
+# version: 1.2.3
+example.com
+bücher.de
+!ok.example.com
+xn--mnchen-3ya.example
+!post.münchen.example
+straße.example
+!mail.xn--strae-oqa.example
+`))
+	if err != nil {
+		t.Fatalf("NewEmailList() error = %v", err)
+	}
+	if list.Version() != "1.2.3" {
+		t.Errorf("Version() = %q, want %q", list.Version(), "1.2.3")
+	}
+
+	type in struct {
+		domain string
+	}
+
+	type want struct {
+		result bool
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "listed domain",
+			in: in{
+				domain: "example.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "subdomain",
+			in: in{
+				domain: "a.b.example.com",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "exception",
+			in: in{
+				domain: "mail.ok.example.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "a-label of listed u-label",
+			in: in{
+				domain: "xn--bcher-kva.de",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "u-label subdomain of listed u-label",
+			in: in{
+				domain: "mail.bücher.de",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "a-label domain of listed a-label",
+			in: in{
+				domain: "mail.xn--mnchen-3ya.example",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "u-label exception under a-label entry",
+			in: in{
+				domain: "post.münchen.example",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "u-label exception queried as a-label",
+			in: in{
+				domain: "a.post.xn--mnchen-3ya.example",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "a-label exception under u-label entry",
+			in: in{
+				domain: "mail.straße.example",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "sibling of a-label exception",
+			in: in{
+				domain: "www.straße.example",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "suffix that is not a parent domain",
+			in: in{
+				domain: "notexample.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := list.ContainsDomain(tt.in.domain)
+			if got != tt.want.result {
+				t.Errorf("ContainsDomain(%q) = %v, want %v", tt.in.domain, got, tt.want.result)
+			}
+		})
+	}
+}
+
+// TestEmailLoadDisposableList swaps the process-wide list, so it must not
+// run in parallel with the tests that read it.
+func TestEmailLoadDisposableList(t *testing.T) {
+	original := validate.EmailDisposableList()
+	defer validate.EmailSetDisposableList(original)
+
+	if original.Version() == "" || original.Len() == 0 {
+		t.Fatalf("embedded list is empty or unversioned")
+	}
+
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	if err := os.WriteFile(path, []byte("# version: override\nexample.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := validate.EmailLoadDisposableListFile(path); err != nil {
+		t.Fatalf("EmailLoadDisposableListFile() error = %v", err)
+	}
+	if !validate.EmailIsDisposable("user@example.com") || validate.EmailIsDisposable("user@mailinator.com") {
+		t.Errorf("override list not in effect")
+	}
+
+	allowed, err := validate.EmailDisposableList().WithExceptions("example.com")
+	if err != nil {
+		t.Fatalf("WithExceptions() error = %v", err)
+	}
+	validate.EmailSetDisposableList(allowed)
+	if validate.EmailIsDisposable("user@example.com") {
+		t.Errorf("exception not in effect")
+	}
+
+	if err := validate.EmailLoadDisposableList(strings.NewReader("bad entry\n")); err == nil {
+		t.Errorf("EmailLoadDisposableList() error = nil, want error")
+	}
+	if validate.EmailDisposableList() != allowed {
+		t.Errorf("failed load replaced the active list")
+	}
+}
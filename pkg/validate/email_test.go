@@ -23,6 +23,8 @@
 package validate_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/progxeno/validate/pkg/validate"
@@ -65,6 +67,46 @@ func TestEmailIsValid(t *testing.T) {
 				err:    nil,
 			},
 		},
+		{
+			name: "internationalized local part and domain",
+			in: in{
+				email: "用户@例子.广告",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "idn domain",
+			in: in{
+				email: "josé@bücher.de",
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "consecutive dots in local part",
+			in: in{
+				email: "john..doe@example.com",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		{
+			name: "missing top-level domain",
+			in: in{
+				email: "user@localhost",
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,3 +118,151 @@ func TestEmailIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		email string
+	}
+
+	type want struct {
+		result *validate.EmailAddress
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ascii address",
+			in: in{
+				email: "test@Example.com",
+			},
+			want: want{
+				result: &validate.EmailAddress{LocalPart: "test", Domain: "example.com", ASCIIDomain: "example.com"},
+				err:    nil,
+			},
+		},
+		{
+			name: "u-label domain",
+			in: in{
+				email: "josé@bücher.de",
+			},
+			want: want{
+				result: &validate.EmailAddress{LocalPart: "josé", Domain: "bücher.de", ASCIIDomain: "xn--bcher-kva.de"},
+				err:    nil,
+			},
+		},
+		{
+			name: "a-label domain",
+			in: in{
+				email: "user@xn--fsqu00a.xn--4rr70v",
+			},
+			want: want{
+				result: &validate.EmailAddress{LocalPart: "user", Domain: "例子.广告", ASCIIDomain: "xn--fsqu00a.xn--4rr70v"},
+				err:    nil,
+			},
+		},
+		{
+			name: "quoted local part",
+			in: in{
+				email: `"john doe"@example.com`,
+			},
+			want: want{
+				result: &validate.EmailAddress{LocalPart: `"john doe"`, Domain: "example.com", ASCIIDomain: "example.com"},
+				err:    nil,
+			},
+		},
+		{
+			name: "missing at sign",
+			in: in{
+				email: "example.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailSyntax,
+			},
+		},
+		{
+			name: "space in local part",
+			in: in{
+				email: "john doe@example.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailLocalPart,
+			},
+		},
+		{
+			name: "local part too long",
+			in: in{
+				email: strings.Repeat("a", 65) + "@example.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailLocalPart,
+			},
+		},
+		{
+			name: "disallowed code point",
+			in: in{
+				email: "user@☃.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailDomain,
+			},
+		},
+		{
+			name: "bidi rule violation",
+			in: in{
+				email: "user@\u05d0\u05d1a.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailDomain,
+			},
+		},
+		{
+			name: "invalid punycode",
+			in: in{
+				email: "user@xn--a.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailDomain,
+			},
+		},
+		{
+			name: "leading hyphen in label",
+			in: in{
+				email: "user@-example.com",
+			},
+			want: want{
+				result: nil,
+				err:    validate.ErrEmailDomain,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.EmailParse(tt.in.email)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("EmailParse(%q) error = %v, want %v", tt.in.email, err, tt.want.err)
+			}
+			if tt.want.result == nil {
+				if got != nil {
+					t.Errorf("EmailParse(%q) = %+v, want nil", tt.in.email, got)
+				}
+				return
+			}
+			if *got != *tt.want.result {
+				t.Errorf("EmailParse(%q) = %+v, want %+v", tt.in.email, got, tt.want.result)
+			}
+		})
+	}
+}
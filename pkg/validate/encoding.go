@@ -0,0 +1,427 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// Encoding is a binary-to-text encoding checked by EncodingValidate.
+type Encoding int
+
+const (
+	// EncodingBase64 is standard base64 with padding (RFC 4648, section 4).
+	EncodingBase64 Encoding = iota
+	EncodingBase64Raw
+	// EncodingBase64URL is the URL and filename safe alphabet with padding
+	// (RFC 4648, section 5).
+	EncodingBase64URL
+	EncodingBase64RawURL
+	EncodingBase32
+	EncodingBase32Raw
+	// EncodingBase58 uses the Bitcoin alphabet.
+	EncodingBase58
+	EncodingHex
+)
+
+type HexCase int
+
+const (
+	HexAnyCase HexCase = iota
+	HexLower
+	HexUpper
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// base58ExactLimit is the largest MaxDecodedSize for which base58
+	// input is decoded to check the size exactly.
+	base58ExactLimit = 4096
+)
+
+var (
+	ErrEncodingInvalid = errors.New("invalid encoding")
+	ErrJSONInvalid     = errors.New("invalid JSON")
+	ErrXMLInvalid      = errors.New("invalid XML")
+	ErrUTF8Invalid     = errors.New("invalid UTF-8")
+	ErrInputTooLarge   = errors.New("input too large")
+)
+
+type EncodingOptions struct {
+	// MaxDecodedSize limits the decoded data in bytes; 0 means no limit.
+	// Base58 limits above 4096 bytes are checked against the largest size
+	// the input length can decode to, at most one byte above the actual
+	// size, so that large inputs are not decoded.
+	MaxDecodedSize int64
+	// HexCase restricts the case of hex digits.
+	HexCase HexCase
+	// AllowOddLength accepts hex strings with an odd number of digits,
+	// e.g. "fff".
+	AllowOddLength bool
+}
+
+func init() {
+	RegisterRule("base64", stringRuleFactory(Base64IsValid, ErrEncodingInvalid))
+	RegisterRule("base64url", stringRuleFactory(Base64URLIsValid, ErrEncodingInvalid))
+	RegisterRule("base32", stringRuleFactory(Base32IsValid, ErrEncodingInvalid))
+	RegisterRule("base58", stringRuleFactory(Base58IsValid, ErrEncodingInvalid))
+	RegisterRule("hex", stringRuleFactory(HexIsValid, ErrEncodingInvalid))
+	RegisterRule("json", stringRuleFactory(JSONIsValid, ErrJSONInvalid))
+	RegisterRule("xml", stringRuleFactory(XMLIsValid, ErrXMLInvalid))
+	RegisterRule("utf8", stringRuleFactory(utf8.ValidString, ErrUTF8Invalid))
+}
+
+func Base64IsValid(s string) bool {
+	return EncodingIsValid(s, EncodingBase64)
+}
+
+// Base64URLIsValid checks for the URL-safe alphabet, with or without
+// padding.
+func Base64URLIsValid(s string) bool {
+	return EncodingIsValid(s, EncodingBase64URL) || EncodingIsValid(s, EncodingBase64RawURL)
+}
+
+func Base32IsValid(s string) bool {
+	return EncodingIsValid(s, EncodingBase32)
+}
+
+func Base58IsValid(s string) bool {
+	return EncodingIsValid(s, EncodingBase58)
+}
+
+// HexIsValid checks if s is an even number of hex digits in either case.
+func HexIsValid(s string) bool {
+	return EncodingIsValid(s, EncodingHex)
+}
+
+func EncodingIsValid(s string, enc Encoding) bool {
+	return EncodingValidate(strings.NewReader(s), enc, EncodingOptions{}) == nil
+}
+
+// EncodingValidate checks that r holds data encoded with enc, decoding it
+// as a stream. Line breaks and other whitespace are rejected. The decoded
+// data is discarded.
+func EncodingValidate(r io.Reader, enc Encoding, opts EncodingOptions) error {
+	var err error
+	switch enc {
+	case EncodingBase64, EncodingBase64Raw, EncodingBase64URL, EncodingBase64RawURL:
+		encoding := map[Encoding]*base64.Encoding{
+			EncodingBase64:       base64.StdEncoding,
+			EncodingBase64Raw:    base64.RawStdEncoding,
+			EncodingBase64URL:    base64.URLEncoding,
+			EncodingBase64RawURL: base64.RawURLEncoding,
+		}[enc].Strict()
+		err = encodingDiscard(base64.NewDecoder(encoding, &encodingNoNewlines{r: r}), opts.MaxDecodedSize)
+	case EncodingBase32, EncodingBase32Raw:
+		encoding := base32.StdEncoding
+		if enc == EncodingBase32Raw {
+			encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+		}
+		err = encodingDiscard(base32.NewDecoder(encoding, &encodingNoNewlines{r: r}), opts.MaxDecodedSize)
+	case EncodingBase58:
+		err = base58Validate(r, opts.MaxDecodedSize)
+	case EncodingHex:
+		err = hexValidate(r, opts)
+	default:
+		return fmt.Errorf("%w: unknown encoding %d", ErrEncodingInvalid, enc)
+	}
+
+	if err != nil && !errors.Is(err, ErrInputTooLarge) && !errors.Is(err, ErrEncodingInvalid) {
+		err = fmt.Errorf("%w: %v", ErrEncodingInvalid, err)
+	}
+	return err
+}
+
+// JSONIsValid checks if s is a single well-formed JSON value.
+func JSONIsValid(s string) bool {
+	return JSONValidate(strings.NewReader(s), 0) == nil
+}
+
+// JSONValidate checks that r holds a single well-formed JSON value without
+// decoding it into memory. maxSize limits the input in bytes; 0 means no
+// limit.
+func JSONValidate(r io.Reader, maxSize int64) error {
+	dec := json.NewDecoder(&sizeLimitReader{r: r, max: maxSize})
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return encodingWrapErr(ErrJSONInvalid, err)
+		}
+		if d, ok := tok.(json.Delim); ok {
+			if d == '{' || d == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			break
+		}
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			return fmt.Errorf("%w: data after top-level value", ErrJSONInvalid)
+		}
+		return encodingWrapErr(ErrJSONInvalid, err)
+	}
+	return nil
+}
+
+// XMLIsValid checks if s is a well-formed XML document with a single root
+// element.
+func XMLIsValid(s string) bool {
+	return XMLValidate(strings.NewReader(s), 0) == nil
+}
+
+// XMLValidate checks that r holds a well-formed XML document with a single
+// root element, reading it as a stream. maxSize limits the input in
+// bytes; 0 means no limit.
+func XMLValidate(r io.Reader, maxSize int64) error {
+	dec := xml.NewDecoder(&sizeLimitReader{r: r, max: maxSize})
+	depth, roots := 0, 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return encodingWrapErr(ErrXMLInvalid, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return fmt.Errorf("%w: text outside the root element", ErrXMLInvalid)
+			}
+		}
+		if roots > 1 {
+			return fmt.Errorf("%w: more than one root element", ErrXMLInvalid)
+		}
+	}
+
+	switch {
+	case roots == 0:
+		return fmt.Errorf("%w: no root element", ErrXMLInvalid)
+	case depth != 0:
+		return fmt.Errorf("%w: unclosed element", ErrXMLInvalid)
+	}
+	return nil
+}
+
+// UTF8Validate checks that r holds valid UTF-8, reading it as a stream.
+// maxSize limits the input in bytes; 0 means no limit.
+func UTF8Validate(r io.Reader, maxSize int64) error {
+	br := bufio.NewReader(&sizeLimitReader{r: r, max: maxSize})
+	for offset := int64(0); ; {
+		c, size, err := br.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return encodingWrapErr(ErrUTF8Invalid, err)
+		}
+		if c == utf8.RuneError && size == 1 {
+			return fmt.Errorf("%w: invalid byte at offset %d", ErrUTF8Invalid, offset)
+		}
+		offset += int64(size)
+	}
+}
+
+// base58Validate checks the alphabet in a single pass. The decoded size
+// is bounded from the input length: each leading '1' is a zero byte, and
+// the remaining digits encode a number in [58^(digits-1), 58^digits),
+// whose byte length is known up to one byte. Only when the bounds
+// straddle maxSize, and maxSize is at most base58ExactLimit, are the
+// digits decoded to settle the size.
+func base58Validate(r io.Reader, maxSize int64) error {
+	br := bufio.NewReader(r)
+	exact := maxSize > 0 && maxSize <= base58ExactLimit
+	var (
+		zeros, digits int64
+		buf           []byte
+	)
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if strings.IndexByte(base58Alphabet, c) < 0 {
+			return fmt.Errorf("%w: invalid base58 character %q", ErrEncodingInvalid, c)
+		}
+		if c == base58Alphabet[0] && digits == 0 {
+			zeros++
+			continue
+		}
+		digits++
+		if maxSize > 0 && zeros+base58MinBytes(digits) > maxSize {
+			return fmt.Errorf("%w: decoded data exceeds %d bytes", ErrInputTooLarge, maxSize)
+		}
+		if exact {
+			buf = append(buf, c)
+		}
+	}
+
+	if maxSize > 0 && zeros+base58MaxBytes(digits) > maxSize {
+		if !exact || zeros+int64(base58Decode(buf).BitLen()+7)/8 > maxSize {
+			return fmt.Errorf("%w: decoded data exceeds %d bytes", ErrInputTooLarge, maxSize)
+		}
+	}
+	return nil
+}
+
+// base58MinBytes and base58MaxBytes return the byte lengths of the
+// smallest and largest numbers with the given count of base58 digits.
+func base58MinBytes(digits int64) int64 {
+	if digits == 0 {
+		return 0
+	}
+	bits := int64(math.Floor(float64(digits-1)*math.Log2(58))) + 1
+	return (bits + 7) / 8
+}
+
+func base58MaxBytes(digits int64) int64 {
+	return int64(math.Ceil(float64(digits) * math.Log2(58) / 8))
+}
+
+func base58Decode(digits []byte) *big.Int {
+	n := new(big.Int)
+	base := big.NewInt(58)
+	for _, c := range digits {
+		n.Mul(n, base).Add(n, big.NewInt(int64(strings.IndexByte(base58Alphabet, c))))
+	}
+	return n
+}
+
+func hexValidate(r io.Reader, opts EncodingOptions) error {
+	br := bufio.NewReader(r)
+	var digits int64
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case c >= '0' && c <= '9':
+		case c >= 'a' && c <= 'f' && opts.HexCase != HexUpper:
+		case c >= 'A' && c <= 'F' && opts.HexCase != HexLower:
+		default:
+			return fmt.Errorf("%w: invalid hex digit %q", ErrEncodingInvalid, c)
+		}
+		digits++
+		if opts.MaxDecodedSize > 0 && (digits+1)/2 > opts.MaxDecodedSize {
+			return fmt.Errorf("%w: decoded data exceeds %d bytes", ErrInputTooLarge, opts.MaxDecodedSize)
+		}
+	}
+	if digits%2 != 0 && !opts.AllowOddLength {
+		return fmt.Errorf("%w: odd number of hex digits", ErrEncodingInvalid)
+	}
+	return nil
+}
+
+func encodingDiscard(r io.Reader, maxSize int64) error {
+	var size int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		size += int64(n)
+		if maxSize > 0 && size > maxSize {
+			return fmt.Errorf("%w: decoded data exceeds %d bytes", ErrInputTooLarge, maxSize)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// encodingWrapErr wraps a decoder error in sentinel unless it is a size
+// limit error.
+func encodingWrapErr(sentinel, err error) error {
+	if errors.Is(err, ErrInputTooLarge) {
+		return err
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: unexpected end of input", sentinel)
+	}
+	return fmt.Errorf("%w: %v", sentinel, err)
+}
+
+// encodingNoNewlines fails on line breaks, which the base64 and base32
+// decoders of the standard library silently skip.
+type encodingNoNewlines struct {
+	r io.Reader
+}
+
+func (n *encodingNoNewlines) Read(p []byte) (int, error) {
+	c, err := n.r.Read(p)
+	if i := bytes.IndexAny(p[:c], "\r\n"); i >= 0 {
+		return i, fmt.Errorf("%w: line break", ErrEncodingInvalid)
+	}
+	return c, err
+}
+
+// sizeLimitReader fails once more than max bytes are read; max 0 means no
+// limit.
+type sizeLimitReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.max > 0 && l.read > l.max {
+		return n, fmt.Errorf("%w: exceeds %d bytes", ErrInputTooLarge, l.max)
+	}
+	return n, err
+}
@@ -0,0 +1,483 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestEncodingValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		enc  validate.Encoding
+		opts validate.EncodingOptions
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "base64",
+			in:   in{s: "aGVsbG8/Pz8+", enc: validate.EncodingBase64},
+			want: want{err: nil},
+		},
+		{
+			name: "base64 padded",
+			in:   in{s: "aGVsbG8=", enc: validate.EncodingBase64},
+			want: want{err: nil},
+		},
+		{
+			name: "base64 missing padding",
+			in:   in{s: "aGVsbG8", enc: validate.EncodingBase64},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base64 raw",
+			in:   in{s: "aGVsbG8", enc: validate.EncodingBase64Raw},
+			want: want{err: nil},
+		},
+		{
+			name: "base64 non-zero padding bits",
+			in:   in{s: "aGVsbG9=", enc: validate.EncodingBase64},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base64 url alphabet",
+			in:   in{s: "aGVsbG8_Pz8-", enc: validate.EncodingBase64},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base64 url",
+			in:   in{s: "aGVsbG8_Pz8-", enc: validate.EncodingBase64URL},
+			want: want{err: nil},
+		},
+		{
+			name: "base64 raw url",
+			in:   in{s: "aGVsbG8_Pz8", enc: validate.EncodingBase64RawURL},
+			want: want{err: nil},
+		},
+		{
+			name: "base64 line break",
+			in:   in{s: "aGVs\nbG8=", enc: validate.EncodingBase64},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base64 too large",
+			in:   in{s: "aGVsbG8=", enc: validate.EncodingBase64, opts: validate.EncodingOptions{MaxDecodedSize: 4}},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+		{
+			name: "base32",
+			in:   in{s: "NBSWY3DP", enc: validate.EncodingBase32},
+			want: want{err: nil},
+		},
+		{
+			name: "base32 padded",
+			in:   in{s: "NBSWY3DPEE======", enc: validate.EncodingBase32},
+			want: want{err: nil},
+		},
+		{
+			name: "base32 missing padding",
+			in:   in{s: "NBSWY3DPEE", enc: validate.EncodingBase32},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base32 raw",
+			in:   in{s: "NBSWY3DPEE", enc: validate.EncodingBase32Raw},
+			want: want{err: nil},
+		},
+		{
+			name: "base32 lowercase",
+			in:   in{s: "nbswy3dp", enc: validate.EncodingBase32},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base58",
+			in:   in{s: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", enc: validate.EncodingBase58},
+			want: want{err: nil},
+		},
+		{
+			name: "base58 excluded character",
+			in:   in{s: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfN0", enc: validate.EncodingBase58},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "base58 too large",
+			in:   in{s: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", enc: validate.EncodingBase58, opts: validate.EncodingOptions{MaxDecodedSize: 24}},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+		{
+			name: "base58 at limit",
+			in:   in{s: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", enc: validate.EncodingBase58, opts: validate.EncodingOptions{MaxDecodedSize: 25}},
+			want: want{err: nil},
+		},
+		{
+			name: "base58 all ones byte at limit",
+			in:   in{s: "JEKNVnkbo3jma5nREBBJCDoXFVeKkD56V3xKrvRmWxFG", enc: validate.EncodingBase58, opts: validate.EncodingOptions{MaxDecodedSize: 32}},
+			want: want{err: nil},
+		},
+		{
+			name: "base58 same length over limit",
+			in:   in{s: "JEKNVnkbo3jma5nREBBJCDoXFVeKkD56V3xKrvRmWxFH", enc: validate.EncodingBase58, opts: validate.EncodingOptions{MaxDecodedSize: 32}},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+		{
+			name: "base58 leading zeros",
+			in:   in{s: "1111", enc: validate.EncodingBase58, opts: validate.EncodingOptions{MaxDecodedSize: 3}},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+		{
+			name: "hex",
+			in:   in{s: "deadBEEF", enc: validate.EncodingHex},
+			want: want{err: nil},
+		},
+		{
+			name: "hex odd length",
+			in:   in{s: "fff", enc: validate.EncodingHex},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "hex odd length allowed",
+			in:   in{s: "fff", enc: validate.EncodingHex, opts: validate.EncodingOptions{AllowOddLength: true}},
+			want: want{err: nil},
+		},
+		{
+			name: "hex lowercase required",
+			in:   in{s: "deadBEEF", enc: validate.EncodingHex, opts: validate.EncodingOptions{HexCase: validate.HexLower}},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "hex uppercase",
+			in:   in{s: "DEADBEEF", enc: validate.EncodingHex, opts: validate.EncodingOptions{HexCase: validate.HexUpper}},
+			want: want{err: nil},
+		},
+		{
+			name: "hex prefix",
+			in:   in{s: "0xdeadbeef", enc: validate.EncodingHex},
+			want: want{err: validate.ErrEncodingInvalid},
+		},
+		{
+			name: "hex too large",
+			in:   in{s: "deadbeef", enc: validate.EncodingHex, opts: validate.EncodingOptions{MaxDecodedSize: 3}},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.EncodingValidate(strings.NewReader(tt.in.s), tt.in.enc, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("EncodingValidate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
+
+// TestBase58LargeInput checks that base58 validation is linear in the
+// input size; decoding 4 MiB into a big integer takes minutes.
+func TestBase58LargeInput(t *testing.T) {
+	t.Parallel()
+
+	s := strings.Repeat("z", 4<<20)
+	if !validate.Base58IsValid(s) {
+		t.Error("Base58IsValid() = false, want true")
+	}
+	for _, limit := range []int64{1 << 10, 1 << 20, 1 << 30} {
+		err := validate.EncodingValidate(strings.NewReader(s), validate.EncodingBase58, validate.EncodingOptions{MaxDecodedSize: limit})
+		if want := limit < 3<<20; errors.Is(err, validate.ErrInputTooLarge) != want {
+			t.Errorf("EncodingValidate(limit %d) = %v, want too large %v", limit, err, want)
+		}
+	}
+}
+
+func TestEncodingIsValid(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		base64    bool
+		base64URL bool
+		base32    bool
+		base58    bool
+		hex       bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "hex digits",
+			in:   in{s: "12345678"},
+			want: want{base64: true, base64URL: true, base32: false, base58: true, hex: true},
+		},
+		{
+			name: "base64 padded",
+			in:   in{s: "aGk="},
+			want: want{base64: true, base64URL: true, base32: false, base58: false, hex: false},
+		},
+		{
+			name: "base64 url raw",
+			in:   in{s: "aGk_-w"},
+			want: want{base64: false, base64URL: true, base32: false, base58: false, hex: false},
+		},
+		{
+			name: "base32",
+			in:   in{s: "NBUQ===="},
+			want: want{base64: false, base64URL: false, base32: true, base58: false, hex: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := want{
+				base64:    validate.Base64IsValid(tt.in.s),
+				base64URL: validate.Base64URLIsValid(tt.in.s),
+				base32:    validate.Base32IsValid(tt.in.s),
+				base58:    validate.Base58IsValid(tt.in.s),
+				hex:       validate.HexIsValid(tt.in.s),
+			}
+			if got != tt.want {
+				t.Errorf("encodings of %q = %+v, want %+v", tt.in.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s       string
+		maxSize int64
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "object",
+			in:   in{s: `{"a": [1, 2, {"b": null}], "c": "d"}`},
+			want: want{err: nil},
+		},
+		{
+			name: "scalar",
+			in:   in{s: ` 42 `},
+			want: want{err: nil},
+		},
+		{
+			name: "trailing comma",
+			in:   in{s: `{"a": 1,}`},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "unclosed",
+			in:   in{s: `{"a": [1, 2}`},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "truncated",
+			in:   in{s: `{"a": [1, 2`},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "two values",
+			in:   in{s: `{} {}`},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "empty",
+			in:   in{s: ``},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "single quotes",
+			in:   in{s: `{'a': 1}`},
+			want: want{err: validate.ErrJSONInvalid},
+		},
+		{
+			name: "too large",
+			in:   in{s: `[` + strings.Repeat(`1,`, 10000) + `1]`, maxSize: 1000},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.JSONValidate(strings.NewReader(tt.in.s), tt.in.maxSize)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("JSONValidate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestXMLValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s       string
+		maxSize int64
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "document",
+			in:   in{s: `<?xml version="1.0"?><!-- c --><a x="1"><b>text</b><c/></a>`},
+			want: want{err: nil},
+		},
+		{
+			name: "mismatched tags",
+			in:   in{s: `<a><b></a></b>`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "unclosed",
+			in:   in{s: `<a><b></b>`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "two roots",
+			in:   in{s: `<a/><b/>`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "text outside root",
+			in:   in{s: `<a/>text`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "no root",
+			in:   in{s: `<?xml version="1.0"?>`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "unquoted attribute",
+			in:   in{s: `<a x=1/>`},
+			want: want{err: validate.ErrXMLInvalid},
+		},
+		{
+			name: "too large",
+			in:   in{s: `<a>` + strings.Repeat(`<b/>`, 10000) + `</a>`, maxSize: 1000},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.XMLValidate(strings.NewReader(tt.in.s), tt.in.maxSize)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("XMLValidate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestUTF8Validate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s       string
+		maxSize int64
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid",
+			in:   in{s: "héllo, 世界 \U0001F600"},
+			want: want{err: nil},
+		},
+		{
+			name: "replacement character",
+			in:   in{s: "�"},
+			want: want{err: nil},
+		},
+		{
+			name: "invalid byte",
+			in:   in{s: "abc\xff"},
+			want: want{err: validate.ErrUTF8Invalid},
+		},
+		{
+			name: "truncated sequence",
+			in:   in{s: "abc\xe4\xb8"},
+			want: want{err: validate.ErrUTF8Invalid},
+		},
+		{
+			name: "surrogate",
+			in:   in{s: "\xed\xa0\x80"},
+			want: want{err: validate.ErrUTF8Invalid},
+		},
+		{
+			name: "too large",
+			in:   in{s: strings.Repeat("a", 5000), maxSize: 4096},
+			want: want{err: validate.ErrInputTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.UTF8Validate(strings.NewReader(tt.in.s), tt.in.maxSize)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("UTF8Validate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	portMaxPrivileged = 1023
+
+	// unixSocketMaxPath is the size of sun_path on Linux minus the
+	// terminating NUL. Other systems are stricter, e.g. 103 on macOS.
+	unixSocketMaxPath = 107
+)
+
+var (
+	ErrPortInvalid     = errors.New("invalid port")
+	ErrPortPrivileged  = fmt.Errorf("%w: privileged port", ErrPortInvalid)
+	ErrEndpointInvalid = errors.New("invalid endpoint")
+	ErrUnixSocketPath  = errors.New("invalid unix socket path")
+)
+
+type PortOptions struct {
+	// AllowZero accepts port 0, which asks the system for an ephemeral
+	// port when listening.
+	AllowZero bool
+	// ForbidPrivileged rejects ports 1-1023, which require elevated
+	// privileges to listen on.
+	ForbidPrivileged bool
+}
+
+type EndpointOptions struct {
+	Port PortOptions
+	// AllowEmptyHost accepts listen addresses such as ":8080".
+	AllowEmptyHost bool
+	// RequireIP rejects hostnames.
+	RequireIP bool
+}
+
+// Endpoint is a parsed "host:port" or "[v6]:port" address.
+type Endpoint struct {
+	Host string
+	// Addr is set when Host is an IP address.
+	Addr netip.Addr
+	Port uint16
+}
+
+func (e *Endpoint) String() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(int(e.Port)))
+}
+
+type UnixSocketOptions struct {
+	// AllowAbstract accepts Linux abstract socket names written with a
+	// leading "@", e.g. "@app".
+	AllowAbstract bool
+	// RequireAbsolute rejects relative paths.
+	RequireAbsolute bool
+	// MaxLength defaults to 107 bytes.
+	MaxLength int
+}
+
+func init() {
+	RegisterRule("port", func(string) (ValidationRule, error) {
+		return portRule(PortOptions{}), nil
+	})
+	RegisterRule("port_unprivileged", func(string) (ValidationRule, error) {
+		return portRule(PortOptions{ForbidPrivileged: true}), nil
+	})
+	RegisterRule("hostport", stringRuleFactory(EndpointIsValid, ErrEndpointInvalid))
+	RegisterRule("unix_socket", stringRuleFactory(UnixSocketPathIsValid, ErrUnixSocketPath))
+}
+
+// PortIsValid checks if s is a port number from 1 to 65535 in decimal
+// without sign or leading zeros.
+func PortIsValid(s string) bool {
+	_, err := PortValidate(s, PortOptions{})
+	return err == nil
+}
+
+func PortIsPrivileged(port int) bool {
+	return port > 0 && port <= portMaxPrivileged
+}
+
+// PortValidate parses and validates the decimal port s.
+func PortValidate(s string, opts PortOptions) (uint16, error) {
+	if s == "" || len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("%w: %q", ErrPortInvalid, s)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrPortInvalid, s)
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q out of range", ErrPortInvalid, s)
+	}
+	if err := PortNumberValidate(int(n), opts); err != nil {
+		return 0, err
+	}
+	return uint16(n), nil
+}
+
+func PortNumberValidate(port int, opts PortOptions) error {
+	switch {
+	case port < 0 || port > 65535:
+		return fmt.Errorf("%w: %d out of range", ErrPortInvalid, port)
+	case port == 0 && !opts.AllowZero:
+		return fmt.Errorf("%w: 0", ErrPortInvalid)
+	case opts.ForbidPrivileged && PortIsPrivileged(port):
+		return fmt.Errorf("%w: %d", ErrPortPrivileged, port)
+	}
+	return nil
+}
+
+// EndpointIsValid checks if s is a "host:port" pair whose host is a
+// hostname or an IPv4 address, or a "[v6]:port" pair.
+func EndpointIsValid(s string) bool {
+	_, err := EndpointParse(s, EndpointOptions{})
+	return err == nil
+}
+
+func EndpointParse(s string, opts EndpointOptions) (*Endpoint, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEndpointInvalid, err)
+	}
+
+	e := &Endpoint{Host: host}
+	if e.Port, err = PortValidate(port, opts.Port); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEndpointInvalid, err)
+	}
+
+	bracketed := strings.HasPrefix(s, "[")
+	switch ip, ipErr := netip.ParseAddr(host); {
+	case host == "":
+		if !opts.AllowEmptyHost {
+			return nil, fmt.Errorf("%w: missing host", ErrEndpointInvalid)
+		}
+	case ipErr == nil:
+		// SplitHostPort accepts "[192.0.2.1]:80", but only IPv6 addresses
+		// may be bracketed, and they must be.
+		if ip.Is6() != bracketed {
+			return nil, fmt.Errorf("%w: %q", ErrEndpointInvalid, s)
+		}
+		e.Addr = ip
+	case bracketed || opts.RequireIP:
+		return nil, fmt.Errorf("%w: %q is not an IP address", ErrEndpointInvalid, host)
+	default:
+		if err := HostnameValidate(host, HostnameOptions{AllowTrailingDot: true}); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEndpointInvalid, err)
+		}
+	}
+	return e, nil
+}
+
+// UnixSocketPathIsValid checks if s fits in a Linux sockaddr_un, either
+// as a path or as an abstract name starting with "@".
+func UnixSocketPathIsValid(s string) bool {
+	return UnixSocketPathValidate(s, UnixSocketOptions{AllowAbstract: true}) == nil
+}
+
+func UnixSocketPathValidate(s string, opts UnixSocketOptions) error {
+	limit := opts.MaxLength
+	if limit <= 0 {
+		limit = unixSocketMaxPath
+	}
+
+	name := s
+	abstract := strings.HasPrefix(s, "@")
+	if abstract {
+		if !opts.AllowAbstract {
+			return fmt.Errorf("%w: abstract socket %q", ErrUnixSocketPath, s)
+		}
+		// The "@" stands for the leading NUL of sun_path, and abstract
+		// names are not NUL-terminated.
+		name = s[1:]
+	}
+
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: empty", ErrUnixSocketPath)
+	case len(name) > limit:
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrUnixSocketPath, len(name), limit)
+	case strings.IndexByte(name, 0) >= 0:
+		return fmt.Errorf("%w: contains NUL", ErrUnixSocketPath)
+	case abstract:
+		return nil
+	case strings.HasSuffix(name, "/"):
+		return fmt.Errorf("%w: %q is a directory", ErrUnixSocketPath, s)
+	case opts.RequireAbsolute && !strings.HasPrefix(name, "/"):
+		return fmt.Errorf("%w: %q is not absolute", ErrUnixSocketPath, s)
+	}
+	return nil
+}
+
+// portRule accepts ports given as strings or as any integer type.
+func portRule(opts PortOptions) ValidationRule {
+	return func(value interface{}) error {
+		v := reflect.ValueOf(value)
+		switch {
+		case v.Kind() == reflect.String:
+			_, err := PortValidate(v.String(), opts)
+			return err
+		case v.CanInt():
+			if n := v.Int(); n < 0 || n > 65535 {
+				return fmt.Errorf("%w: %d out of range", ErrPortInvalid, n)
+			}
+			return PortNumberValidate(int(v.Int()), opts)
+		case v.CanUint():
+			if n := v.Uint(); n > 65535 {
+				return fmt.Errorf("%w: %d out of range", ErrPortInvalid, n)
+			}
+			return PortNumberValidate(int(v.Uint()), opts)
+		}
+		return fmt.Errorf("%w: %T, want string or integer", ErrUnsupportedType, value)
+	}
+}
@@ -0,0 +1,377 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestPortValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.PortOptions
+	}
+
+	type want struct {
+		port uint16
+		err  error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid",
+			in:   in{s: "8080"},
+			want: want{port: 8080},
+		},
+		{
+			name: "maximum",
+			in:   in{s: "65535"},
+			want: want{port: 65535},
+		},
+		{
+			name: "out of range",
+			in:   in{s: "65536"},
+			want: want{err: validate.ErrPortInvalid},
+		},
+		{
+			name: "zero",
+			in:   in{s: "0"},
+			want: want{err: validate.ErrPortInvalid},
+		},
+		{
+			name: "zero allowed",
+			in:   in{s: "0", opts: validate.PortOptions{AllowZero: true}},
+			want: want{port: 0},
+		},
+		{
+			name: "leading zero",
+			in:   in{s: "080"},
+			want: want{err: validate.ErrPortInvalid},
+		},
+		{
+			name: "sign",
+			in:   in{s: "+80"},
+			want: want{err: validate.ErrPortInvalid},
+		},
+		{
+			name: "privileged",
+			in:   in{s: "443"},
+			want: want{port: 443},
+		},
+		{
+			name: "privileged forbidden",
+			in:   in{s: "443", opts: validate.PortOptions{ForbidPrivileged: true}},
+			want: want{err: validate.ErrPortPrivileged},
+		},
+		{
+			name: "unprivileged",
+			in:   in{s: "1024", opts: validate.PortOptions{ForbidPrivileged: true}},
+			want: want{port: 1024},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.PortValidate(tt.in.s, tt.in.opts)
+			if got != tt.want.port || tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("PortValidate(%q) = %d, %v, want %d, %v", tt.in.s, got, err, tt.want.port, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestEndpointParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.EndpointOptions
+	}
+
+	type want struct {
+		host string
+		ip   bool
+		port uint16
+		err  bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "hostname",
+			in:   in{s: "example.com:443"},
+			want: want{host: "example.com", port: 443},
+		},
+		{
+			name: "ipv4",
+			in:   in{s: "192.0.2.1:8080"},
+			want: want{host: "192.0.2.1", ip: true, port: 8080},
+		},
+		{
+			name: "ipv6",
+			in:   in{s: "[2001:db8::1]:53"},
+			want: want{host: "2001:db8::1", ip: true, port: 53},
+		},
+		{
+			name: "ipv6 with zone",
+			in:   in{s: "[fe80::1%eth0]:53"},
+			want: want{host: "fe80::1%eth0", ip: true, port: 53},
+		},
+		{
+			name: "ipv6 without brackets",
+			in:   in{s: "2001:db8::1:53"},
+			want: want{err: true},
+		},
+		{
+			name: "bracketed ipv4",
+			in:   in{s: "[192.0.2.1]:80"},
+			want: want{err: true},
+		},
+		{
+			name: "bracketed hostname",
+			in:   in{s: "[example.com]:80"},
+			want: want{err: true},
+		},
+		{
+			name: "missing port",
+			in:   in{s: "example.com"},
+			want: want{err: true},
+		},
+		{
+			name: "invalid port",
+			in:   in{s: "example.com:http"},
+			want: want{err: true},
+		},
+		{
+			name: "invalid hostname",
+			in:   in{s: "exa_mple.com:80"},
+			want: want{err: true},
+		},
+		{
+			name: "empty host",
+			in:   in{s: ":8080"},
+			want: want{err: true},
+		},
+		{
+			name: "empty host allowed",
+			in:   in{s: ":8080", opts: validate.EndpointOptions{AllowEmptyHost: true}},
+			want: want{host: "", port: 8080},
+		},
+		{
+			name: "hostname with ip required",
+			in:   in{s: "localhost:80", opts: validate.EndpointOptions{RequireIP: true}},
+			want: want{err: true},
+		},
+		{
+			name: "privileged port forbidden",
+			in:   in{s: "127.0.0.1:80", opts: validate.EndpointOptions{Port: validate.PortOptions{ForbidPrivileged: true}}},
+			want: want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.EndpointParse(tt.in.s, tt.in.opts)
+			if tt.want.err {
+				if err == nil || !errors.Is(err, validate.ErrEndpointInvalid) {
+					t.Errorf("EndpointParse(%q) error = %v, want %v", tt.in.s, err, validate.ErrEndpointInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EndpointParse(%q) error = %v", tt.in.s, err)
+			}
+			if got.Host != tt.want.host || got.Addr.IsValid() != tt.want.ip || got.Port != tt.want.port {
+				t.Errorf("EndpointParse(%q) = %+v, want %+v", tt.in.s, got, tt.want)
+			}
+			if got.String() != tt.in.s {
+				t.Errorf("String() = %q, want %q", got.String(), tt.in.s)
+			}
+		})
+	}
+}
+
+func TestUnixSocketPathValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.UnixSocketOptions
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "absolute",
+			in:   in{s: "/run/app.sock"},
+			want: want{result: true},
+		},
+		{
+			name: "relative",
+			in:   in{s: "app.sock"},
+			want: want{result: true},
+		},
+		{
+			name: "relative with absolute required",
+			in:   in{s: "app.sock", opts: validate.UnixSocketOptions{RequireAbsolute: true}},
+			want: want{result: false},
+		},
+		{
+			name: "at limit",
+			in:   in{s: "/" + strings.Repeat("a", 106)},
+			want: want{result: true},
+		},
+		{
+			name: "too long",
+			in:   in{s: "/" + strings.Repeat("a", 107)},
+			want: want{result: false},
+		},
+		{
+			name: "custom limit",
+			in:   in{s: "/" + strings.Repeat("a", 103), opts: validate.UnixSocketOptions{MaxLength: 103}},
+			want: want{result: false},
+		},
+		{
+			name: "abstract not allowed",
+			in:   in{s: "@app"},
+			want: want{result: false},
+		},
+		{
+			name: "abstract",
+			in:   in{s: "@app", opts: validate.UnixSocketOptions{AllowAbstract: true}},
+			want: want{result: true},
+		},
+		{
+			name: "empty abstract",
+			in:   in{s: "@", opts: validate.UnixSocketOptions{AllowAbstract: true}},
+			want: want{result: false},
+		},
+		{
+			name: "directory",
+			in:   in{s: "/run/"},
+			want: want{result: false},
+		},
+		{
+			name: "nul",
+			in:   in{s: "/run/a\x00b"},
+			want: want{result: false},
+		},
+		{
+			name: "empty",
+			in:   in{s: ""},
+			want: want{result: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.UnixSocketPathValidate(tt.in.s, tt.in.opts)
+			if (err == nil) != tt.want.result {
+				t.Errorf("UnixSocketPathValidate(%q) = %v, want %v", tt.in.s, err, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestPortRule(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag   string
+		value interface{}
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "string",
+			in:   in{tag: "port", value: "8080"},
+			want: want{err: nil},
+		},
+		{
+			name: "int",
+			in:   in{tag: "port", value: 8080},
+			want: want{err: nil},
+		},
+		{
+			name: "uint16",
+			in:   in{tag: "port", value: uint16(443)},
+			want: want{err: nil},
+		},
+		{
+			name: "int out of range",
+			in:   in{tag: "port", value: int64(70000)},
+			want: want{err: validate.ErrPortInvalid},
+		},
+		{
+			name: "unprivileged",
+			in:   in{tag: "port_unprivileged", value: 443},
+			want: want{err: validate.ErrPortPrivileged},
+		},
+		{
+			name: "unsupported type",
+			in:   in{tag: "port", value: 80.0},
+			want: want{err: validate.ErrUnsupportedType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validate.NewValidate()
+			if err := v.AddNamedRule(tt.in.tag); err != nil {
+				t.Fatalf("AddNamedRule(%q) error = %v", tt.in.tag, err)
+			}
+			err := v.Validate(tt.in.value)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("Validate(%v) = %v, want %v", tt.in.value, err, tt.want.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BIC is a parsed ISO 9362 Business Identifier Code (SWIFT code).
+// BranchCode is empty for 8-character BICs.
+type BIC struct {
+	BankCode     string
+	CountryCode  string
+	LocationCode string
+	BranchCode   string
+}
+
+// ABARouting is a parsed US ABA routing transit number.
+type ABARouting struct {
+	FederalReserveSymbol string
+	InstitutionID        string
+	CheckDigit           byte
+}
+
+// CUSIP is a parsed CUSIP security identifier.
+type CUSIP struct {
+	Issuer     string
+	Issue      string
+	CheckDigit byte
+}
+
+// SEDOL is a parsed Stock Exchange Daily Official List number.
+type SEDOL struct {
+	Code       string
+	CheckDigit byte
+}
+
+// LEI is a parsed ISO 17442 Legal Entity Identifier.
+type LEI struct {
+	LOU         string
+	Entity      string
+	CheckDigits string
+}
+
+func init() {
+	RegisterRule("bic", checkDigitRuleFactory(func(s string) error {
+		_, err := BICParse(s)
+		return err
+	}))
+	RegisterRule("aba_routing", checkDigitRuleFactory(func(s string) error {
+		_, err := ABARoutingParse(s)
+		return err
+	}))
+	RegisterRule("sort_code", checkDigitRuleFactory(func(s string) error {
+		_, err := SortCodeParse(s)
+		return err
+	}))
+	RegisterRule("cusip", checkDigitRuleFactory(func(s string) error {
+		_, err := CUSIPParse(s)
+		return err
+	}))
+	RegisterRule("sedol", checkDigitRuleFactory(func(s string) error {
+		_, err := SEDOLParse(s)
+		return err
+	}))
+	RegisterRule("lei", checkDigitRuleFactory(func(s string) error {
+		_, err := LEIParse(s)
+		return err
+	}))
+}
+
+// String returns the BIC in its 8 or 11 character form.
+func (b *BIC) String() string {
+	return b.BankCode + b.CountryCode + b.LocationCode + b.BranchCode
+}
+
+// IsTest reports whether the BIC belongs to a test and training
+// institution, marked by a 0 as second location character.
+func (b *BIC) IsTest() bool {
+	return b.LocationCode[1] == '0'
+}
+
+// IsPrimaryOffice reports whether the BIC identifies the primary office
+// of the institution rather than a branch.
+func (b *BIC) IsPrimaryOffice() bool {
+	return b.BranchCode == "" || b.BranchCode == "XXX"
+}
+
+// BICParse validates an 8 or 11 character BIC such as "DEUTDEFF500": a
+// 4-character institution code, an ISO 3166 country code, a 2-character
+// location code and an optional 3-character branch code. Lower-case
+// letters are accepted.
+func BICParse(s string) (*BIC, error) {
+	bic := strings.ToUpper(s)
+	if len(bic) != 8 && len(bic) != 11 || !checkDigitIsAlnum(bic) {
+		return nil, checkDigitFormatErr("BIC", s, "want 8 or 11 letters or digits")
+	}
+	if !CountryIsValid(bic[4:6]) {
+		return nil, checkDigitFormatErr("BIC", s, "unknown country "+bic[4:6])
+	}
+	if bic[6] == '0' || bic[6] == '1' {
+		return nil, checkDigitFormatErr("BIC", s, "location code cannot start with 0 or 1")
+	}
+	if len(bic) == 11 && bic[8] == 'X' && bic[8:] != "XXX" {
+		return nil, checkDigitFormatErr("BIC", s, "branch code cannot start with X")
+	}
+	return &BIC{BankCode: bic[:4], CountryCode: bic[4:6], LocationCode: bic[6:8], BranchCode: bic[8:]}, nil
+}
+
+func BICIsValid(s string) bool {
+	_, err := BICParse(s)
+	return err == nil
+}
+
+// ABARoutingParse validates a 9-digit ABA routing number: the Federal
+// Reserve routing symbol, whose first two digits must be a valid prefix,
+// the institution identifier and a check digit with weights 3, 7, 1.
+func ABARoutingParse(s string) (*ABARouting, error) {
+	if len(s) != 9 || !checkDigitIsDigits(s) {
+		return nil, checkDigitFormatErr("ABA routing number", s, "want 9 digits")
+	}
+	switch prefix := (s[0]-'0')*10 + s[1] - '0'; {
+	case prefix <= 12, prefix >= 21 && prefix <= 32, prefix >= 61 && prefix <= 72, prefix == 80:
+	default:
+		return nil, checkDigitFormatErr("ABA routing number", s, fmt.Sprintf("invalid prefix %02d", prefix))
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(s[i]-'0') * [3]int{3, 7, 1}[i%3]
+	}
+	if sum%10 != 0 {
+		return nil, checkDigitMismatchErr("ABA routing number", s)
+	}
+	return &ABARouting{FederalReserveSymbol: s[:4], InstitutionID: s[4:8], CheckDigit: s[8]}, nil
+}
+
+func ABARoutingIsValid(s string) bool {
+	_, err := ABARoutingParse(s)
+	return err == nil
+}
+
+// SortCodeParse validates a UK sort code written as "12-34-56",
+// "12 34 56" or "123456" and returns its six digits. Sort codes have no
+// check digit.
+func SortCodeParse(s string) (string, error) {
+	code := s
+	if len(s) == 8 && (s[2] == '-' && s[5] == '-' || s[2] == ' ' && s[5] == ' ') {
+		code = s[:2] + s[3:5] + s[6:]
+	}
+	if len(code) != 6 || !checkDigitIsDigits(code) {
+		return "", checkDigitFormatErr("sort code", s, "want NN-NN-NN")
+	}
+	return code, nil
+}
+
+func SortCodeIsValid(s string) bool {
+	_, err := SortCodeParse(s)
+	return err == nil
+}
+
+// CUSIPParse validates a 9-character CUSIP: a 6-character issuer code, a
+// 2-character issue number and a modified Luhn check digit, where letters
+// count as 10 to 35 and *, @ and # as 36 to 38.
+func CUSIPParse(s string) (*CUSIP, error) {
+	if len(s) != 9 || !checkDigitIsDigits(s[8:]) {
+		return nil, checkDigitFormatErr("CUSIP", s, "want 8 letters or digits and a digit")
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		v := strings.IndexByte(cusipAlphabet, s[i])
+		if v < 0 {
+			return nil, checkDigitFormatErr("CUSIP", s, "want 8 letters or digits and a digit")
+		}
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+	if byte('0'+(10-sum%10)%10) != s[8] {
+		return nil, checkDigitMismatchErr("CUSIP", s)
+	}
+	return &CUSIP{Issuer: s[:6], Issue: s[6:8], CheckDigit: s[8]}, nil
+}
+
+func CUSIPIsValid(s string) bool {
+	_, err := CUSIPParse(s)
+	return err == nil
+}
+
+// SEDOLParse validates a 7-character SEDOL: six digits or consonants and
+// a check digit with weights 1, 3, 1, 7, 3, 9.
+func SEDOLParse(s string) (*SEDOL, error) {
+	if len(s) != 7 || !checkDigitIsAlnum(s[:6]) || strings.ContainsAny(s[:6], "AEIOU") || !checkDigitIsDigits(s[6:]) {
+		return nil, checkDigitFormatErr("SEDOL", s, "want 6 digits or consonants and a digit")
+	}
+	sum := 0
+	for i := 0; i < 6; i++ {
+		sum += strings.IndexByte(cusipAlphabet, s[i]) * [6]int{1, 3, 1, 7, 3, 9}[i]
+	}
+	if byte('0'+(10-sum%10)%10) != s[6] {
+		return nil, checkDigitMismatchErr("SEDOL", s)
+	}
+	return &SEDOL{Code: s[:6], CheckDigit: s[6]}, nil
+}
+
+// SEDOLValidate is like SEDOLParse but only returns the error.
+func SEDOLValidate(s string) error {
+	_, err := SEDOLParse(s)
+	return err
+}
+
+func SEDOLIsValid(s string) bool {
+	_, err := SEDOLParse(s)
+	return err == nil
+}
+
+// LEIParse validates a 20-character LEI: the 4-character prefix of the
+// issuing Local Operating Unit, a 14-character entity part and two ISO
+// 7064 mod 97-10 check digits.
+func LEIParse(s string) (*LEI, error) {
+	if len(s) != 20 || !checkDigitIsAlnum(s[:18]) || !checkDigitIsDigits(s[18:]) {
+		return nil, checkDigitFormatErr("LEI", s, "want 18 letters or digits and 2 digits")
+	}
+	if s[18:] == "00" || s[18:] == "01" || ibanMod97(s) != 1 {
+		return nil, checkDigitMismatchErr("LEI", s)
+	}
+	return &LEI{LOU: s[:4], Entity: s[4:18], CheckDigits: s[18:]}, nil
+}
+
+func LEIIsValid(s string) bool {
+	_, err := LEIParse(s)
+	return err == nil
+}
+
+// cusipAlphabet maps CUSIP and SEDOL characters to their values.
+const cusipAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ*@#"
@@ -0,0 +1,225 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestBICParse(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		bic     validate.BIC
+		primary bool
+		test    bool
+		err     error
+	}
+
+	tests := []struct {
+		in   string
+		want want
+	}{
+		{in: "DEUTDEFF", want: want{bic: validate.BIC{BankCode: "DEUT", CountryCode: "DE", LocationCode: "FF"}, primary: true}},
+		{in: "DEUTDEFF500", want: want{bic: validate.BIC{BankCode: "DEUT", CountryCode: "DE", LocationCode: "FF", BranchCode: "500"}}},
+		{in: "nedszajjxxx", want: want{bic: validate.BIC{BankCode: "NEDS", CountryCode: "ZA", LocationCode: "JJ", BranchCode: "XXX"}, primary: true}},
+		{in: "DEUTDEF0", want: want{bic: validate.BIC{BankCode: "DEUT", CountryCode: "DE", LocationCode: "F0"}, primary: true, test: true}},
+		{in: "DEUTZZFF", want: want{err: validate.ErrCheckDigitFormat}},
+		{in: "DEUTDE1F", want: want{err: validate.ErrCheckDigitFormat}},
+		{in: "DEUTDEFFX50", want: want{err: validate.ErrCheckDigitFormat}},
+		{in: "DEUTDEFF50", want: want{err: validate.ErrCheckDigitFormat}},
+		{in: "DEUT-DEFF", want: want{err: validate.ErrCheckDigitFormat}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			bic, err := validate.BICParse(tt.in)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("BICParse(%q) error = %v, want %v", tt.in, err, tt.want.err)
+			}
+			if err != nil {
+				return
+			}
+			if *bic != tt.want.bic || bic.IsPrimaryOffice() != tt.want.primary || bic.IsTest() != tt.want.test {
+				t.Errorf("BICParse(%q) = %+v, want %+v", tt.in, *bic, tt.want.bic)
+			}
+		})
+	}
+}
+
+func TestABARoutingParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{in: "011000015"},
+		{in: "021000021"},
+		{in: "122105155"},
+		{in: "021000022", want: validate.ErrCheckDigitMismatch},
+		{in: "131000019", want: validate.ErrCheckDigitFormat},
+		{in: "02100002", want: validate.ErrCheckDigitFormat},
+		{in: "02100002a", want: validate.ErrCheckDigitFormat},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			aba, err := validate.ABARoutingParse(tt.in)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("ABARoutingParse(%q) error = %v, want %v", tt.in, err, tt.want)
+			}
+			if err == nil && aba.FederalReserveSymbol+aba.InstitutionID+string(aba.CheckDigit) != tt.in {
+				t.Errorf("ABARoutingParse(%q) = %+v", tt.in, *aba)
+			}
+		})
+	}
+}
+
+func TestSortCodeParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "12-34-56", want: "123456"},
+		{in: "12 34 56", want: "123456"},
+		{in: "123456", want: "123456"},
+		{in: "12-34 56"},
+		{in: "12345"},
+		{in: "12-34-5a"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.SortCodeParse(tt.in)
+			if got != tt.want || (err == nil) != (tt.want != "") {
+				t.Errorf("SortCodeParse(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		validate func(string) error
+		in       string
+		want     error
+	}{
+		{name: "CUSIP", validate: cusipValidate, in: "037833100"},
+		{name: "CUSIP letters", validate: cusipValidate, in: "38259P508"},
+		{name: "CUSIP mismatch", validate: cusipValidate, in: "037833101", want: validate.ErrCheckDigitMismatch},
+		{name: "CUSIP lower-case", validate: cusipValidate, in: "38259p508", want: validate.ErrCheckDigitFormat},
+		{name: "CUSIP length", validate: cusipValidate, in: "03783310", want: validate.ErrCheckDigitFormat},
+		{name: "SEDOL", validate: validate.SEDOLValidate, in: "0263494"},
+		{name: "SEDOL letters", validate: validate.SEDOLValidate, in: "B0YBKJ7"},
+		{name: "SEDOL mismatch", validate: validate.SEDOLValidate, in: "0263495", want: validate.ErrCheckDigitMismatch},
+		{name: "SEDOL vowel", validate: validate.SEDOLValidate, in: "A0YBKJ7", want: validate.ErrCheckDigitFormat},
+		{name: "SEDOL parse", validate: sedolValidate, in: "B0YBKJ7"},
+		{name: "SEDOL parse length", validate: sedolValidate, in: "B0YBKJ", want: validate.ErrCheckDigitFormat},
+		{name: "LEI", validate: leiValidate, in: "5493001KJTIIGC8Y1R12"},
+		{name: "LEI without reserved zeros", validate: leiValidate, in: "7LTWFZYICNSX8D621K86"},
+		{name: "LEI mismatch", validate: leiValidate, in: "5493001KJTIIGC8Y1R13", want: validate.ErrCheckDigitMismatch},
+		{name: "LEI length", validate: leiValidate, in: "5493001KJTIIGC8Y1R1", want: validate.ErrCheckDigitFormat},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.validate(tt.in)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("validate(%q) = %v, want %v", tt.in, err, tt.want)
+			}
+		})
+	}
+
+	cusip, err := validate.CUSIPParse("38259P508")
+	if err != nil || cusip.Issuer != "38259P" || cusip.Issue != "50" || cusip.CheckDigit != '8' {
+		t.Errorf("CUSIPParse() = %+v, %v", cusip, err)
+	}
+	sedol, err := validate.SEDOLParse("B0YBKJ7")
+	if err != nil || sedol.Code != "B0YBKJ" || sedol.CheckDigit != '7' {
+		t.Errorf("SEDOLParse() = %+v, %v", sedol, err)
+	}
+	lei, err := validate.LEIParse("5493001KJTIIGC8Y1R12")
+	if err != nil || lei.LOU != "5493" || lei.Entity != "001KJTIIGC8Y1R" || lei.CheckDigits != "12" {
+		t.Errorf("LEIParse() = %+v, %v", lei, err)
+	}
+}
+
+func TestFinancialRules(t *testing.T) {
+	t.Parallel()
+
+	type payment struct {
+		BIC      string `validate:"bic"`
+		Routing  string `validate:"aba_routing"`
+		SortCode string `validate:"sort_code"`
+		CUSIP    string `validate:"cusip"`
+		SEDOL    string `validate:"sedol"`
+		LEI      string `validate:"lei"`
+	}
+
+	valid := payment{BIC: "DEUTDEFF", Routing: "021000021", SortCode: "12-34-56", CUSIP: "037833100", SEDOL: "0263494", LEI: "5493001KJTIIGC8Y1R12"}
+	if err := validate.ValidateStruct(valid); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+
+	var fieldErrs validate.FieldErrors
+	err := validate.ValidateStruct(payment{BIC: "DEUTZZFF", Routing: "021000022", SortCode: "1234", CUSIP: "037833101", SEDOL: "0263495", LEI: "5493001KJTIIGC8Y1R13"})
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 6 {
+		t.Errorf("ValidateStruct() error = %v, want 6 field errors", err)
+	}
+}
+
+func cusipValidate(s string) error {
+	_, err := validate.CUSIPParse(s)
+	return err
+}
+
+func sedolValidate(s string) error {
+	_, err := validate.SEDOLParse(s)
+	return err
+}
+
+func leiValidate(s string) error {
+	_, err := validate.LEIParse(s)
+	return err
+}
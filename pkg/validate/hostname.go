@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	hostnameMaxLength = 253
+	dnsLabelMaxLength = 63
+)
+
+var (
+	ErrHostnameInvalid = errors.New("invalid hostname")
+	ErrHostnameTooLong = fmt.Errorf("%w: too long", ErrHostnameInvalid)
+	ErrDNSLabelInvalid = fmt.Errorf("%w: invalid DNS label", ErrHostnameInvalid)
+)
+
+type HostnameOptions struct {
+	// AllowTrailingDot accepts absolute names such as "example.com.".
+	AllowTrailingDot bool
+	// AllowUnderscore accepts underscores in labels, as used by SRV and
+	// other service records like "_sip._tcp.example.com".
+	AllowUnderscore bool
+	// RequireFQDN requires at least two labels.
+	RequireFQDN bool
+}
+
+func init() {
+	RegisterRule("hostname", stringRuleFactory(HostnameIsValid, ErrHostnameInvalid))
+	RegisterRule("fqdn", stringRuleFactory(FQDNIsValid, ErrHostnameInvalid))
+}
+
+// HostnameIsValid checks if s is a hostname per RFC 1123: dot-separated
+// labels of letters, digits and hyphens. Internationalized names must be
+// given in their A-label form.
+func HostnameIsValid(s string) bool {
+	return HostnameValidate(s, HostnameOptions{AllowTrailingDot: true}) == nil
+}
+
+// FQDNIsValid checks if s is a fully qualified domain name with at least
+// two labels, with or without the trailing dot.
+func FQDNIsValid(s string) bool {
+	return HostnameValidate(s, HostnameOptions{AllowTrailingDot: true, RequireFQDN: true}) == nil
+}
+
+func DNSLabelIsValid(s string) bool {
+	return dnsValidateLabel(s, false) == nil
+}
+
+func HostnameValidate(s string, opts HostnameOptions) error {
+	name := s
+	if strings.HasSuffix(name, ".") {
+		if !opts.AllowTrailingDot {
+			return fmt.Errorf("%w: trailing dot", ErrHostnameInvalid)
+		}
+		name = name[:len(name)-1]
+	}
+	if name == "" {
+		return fmt.Errorf("%w: empty", ErrHostnameInvalid)
+	}
+	if len(name) > hostnameMaxLength {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrHostnameTooLong, len(name), hostnameMaxLength)
+	}
+
+	labels := strings.Split(name, ".")
+	if opts.RequireFQDN && len(labels) < 2 {
+		return fmt.Errorf("%w: not fully qualified", ErrHostnameInvalid)
+	}
+	for _, label := range labels {
+		if err := dnsValidateLabel(label, opts.AllowUnderscore); err != nil {
+			return err
+		}
+	}
+
+	// An all-numeric top-level label would make the name ambiguous with
+	// an IPv4 address (RFC 3696, section 2).
+	if tld := labels[len(labels)-1]; len(labels) > 1 && strings.Trim(tld, "0123456789") == "" {
+		return fmt.Errorf("%w: numeric top-level domain", ErrHostnameInvalid)
+	}
+	return nil
+}
+
+func dnsValidateLabel(label string, allowUnderscore bool) error {
+	switch {
+	case label == "":
+		return fmt.Errorf("%w: empty label", ErrDNSLabelInvalid)
+	case len(label) > dnsLabelMaxLength:
+		return fmt.Errorf("%w: %q longer than %d bytes", ErrDNSLabelInvalid, label, dnsLabelMaxLength)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Errorf("%w: %q starts or ends with a hyphen", ErrDNSLabelInvalid, label)
+	}
+
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+		case c == '_' && allowUnderscore:
+		default:
+			return fmt.Errorf("%w: %q contains %q", ErrDNSLabelInvalid, label, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestHostnameValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.HostnameOptions
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "simple",
+			in:   in{s: "example.com"},
+			want: want{err: nil},
+		},
+		{
+			name: "single label",
+			in:   in{s: "localhost"},
+			want: want{err: nil},
+		},
+		{
+			name: "single label with fqdn required",
+			in:   in{s: "localhost", opts: validate.HostnameOptions{RequireFQDN: true}},
+			want: want{err: validate.ErrHostnameInvalid},
+		},
+		{
+			name: "leading digit",
+			in:   in{s: "3com.example"},
+			want: want{err: nil},
+		},
+		{
+			name: "trailing dot not allowed",
+			in:   in{s: "example.com."},
+			want: want{err: validate.ErrHostnameInvalid},
+		},
+		{
+			name: "trailing dot allowed",
+			in:   in{s: "example.com.", opts: validate.HostnameOptions{AllowTrailingDot: true}},
+			want: want{err: nil},
+		},
+		{
+			name: "empty label",
+			in:   in{s: "example..com"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "leading hyphen",
+			in:   in{s: "-example.com"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "trailing hyphen",
+			in:   in{s: "example-.com"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "underscore not allowed",
+			in:   in{s: "_sip._tcp.example.com"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "underscore allowed",
+			in:   in{s: "_sip._tcp.example.com", opts: validate.HostnameOptions{AllowUnderscore: true}},
+			want: want{err: nil},
+		},
+		{
+			name: "label too long",
+			in:   in{s: strings.Repeat("a", 64) + ".com"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "label at limit",
+			in:   in{s: strings.Repeat("a", 63) + ".com"},
+			want: want{err: nil},
+		},
+		{
+			name: "name too long",
+			in:   in{s: strings.Repeat(strings.Repeat("a", 63)+".", 4) + "com"},
+			want: want{err: validate.ErrHostnameTooLong},
+		},
+		{
+			name: "numeric tld",
+			in:   in{s: "192.168.1.1"},
+			want: want{err: validate.ErrHostnameInvalid},
+		},
+		{
+			name: "u-label",
+			in:   in{s: "bücher.de"},
+			want: want{err: validate.ErrDNSLabelInvalid},
+		},
+		{
+			name: "a-label",
+			in:   in{s: "xn--bcher-kva.de"},
+			want: want{err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.HostnameValidate(tt.in.s, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("HostnameValidate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestHostnameIsValid(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		hostname bool
+		fqdn     bool
+		label    bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "label",
+			in:   in{s: "localhost"},
+			want: want{hostname: true, fqdn: false, label: true},
+		},
+		{
+			name: "fqdn",
+			in:   in{s: "www.example.com"},
+			want: want{hostname: true, fqdn: true, label: false},
+		},
+		{
+			name: "fqdn with trailing dot",
+			in:   in{s: "www.example.com."},
+			want: want{hostname: true, fqdn: true, label: false},
+		},
+		{
+			name: "empty",
+			in:   in{s: ""},
+			want: want{hostname: false, fqdn: false, label: false},
+		},
+		{
+			name: "root",
+			in:   in{s: "."},
+			want: want{hostname: false, fqdn: false, label: false},
+		},
+		{
+			name: "space",
+			in:   in{s: "exa mple.com"},
+			want: want{hostname: false, fqdn: false, label: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.HostnameIsValid(tt.in.s); got != tt.want.hostname {
+				t.Errorf("HostnameIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.hostname)
+			}
+			if got := validate.FQDNIsValid(tt.in.s); got != tt.want.fqdn {
+				t.Errorf("FQDNIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.fqdn)
+			}
+			if got := validate.DNSLabelIsValid(tt.in.s); got != tt.want.label {
+				t.Errorf("DNSLabelIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.label)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+var (
+	ibanFormats     atomic.Value // *IBANFormats
+	ibanFormatsOnce sync.Once
+)
+
+// IBAN is a parsed International Bank Account Number.
+type IBAN struct {
+	CountryCode string
+	CheckDigits string
+	BBAN        string
+}
+
+// IBANFormats is an immutable table of IBAN formats by country.
+type IBANFormats struct {
+	formats map[string]ibanFormat
+}
+
+// ibanFormat is a registry entry: the IBAN length and the BBAN structure
+// as a list of fixed-length character classes.
+type ibanFormat struct {
+	length int
+	parts  []ibanPart
+}
+
+type ibanPart struct {
+	n     int
+	class byte // 'n' digits, 'a' upper-case letters, 'c' either
+}
+
+// String returns the IBAN in electronic format without spaces.
+func (i *IBAN) String() string {
+	return i.CountryCode + i.CheckDigits + i.BBAN
+}
+
+// Print returns the IBAN in print format, in groups of four characters.
+func (i *IBAN) Print() string {
+	s := i.String()
+	var b strings.Builder
+	for j := 0; j < len(s); j += 4 {
+		if j > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s[j:minInt(j+4, len(s))])
+	}
+	return b.String()
+}
+
+// IBANParse validates an IBAN in electronic or print format against the
+// length and BBAN structure registered for its country and the ISO 7064
+// mod 97-10 check digits. Lower-case letters are accepted.
+func IBANParse(s string) (*IBAN, error) {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 5 || !checkDigitIsUpper(iban[:2]) || !checkDigitIsDigits(iban[2:4]) {
+		return nil, checkDigitFormatErr("IBAN", s, "want country code and check digits")
+	}
+	format, ok := IBANFormatTable().formats[iban[:2]]
+	if !ok {
+		return nil, checkDigitFormatErr("IBAN", s, "unknown country "+iban[:2])
+	}
+	if len(iban) != format.length {
+		return nil, checkDigitFormatErr("IBAN", s, fmt.Sprintf("want %d characters for %s", format.length, iban[:2]))
+	}
+	bban := iban[4:]
+	pos := 0
+	for _, p := range format.parts {
+		part := bban[pos : pos+p.n]
+		valid := false
+		switch p.class {
+		case 'n':
+			valid = checkDigitIsDigits(part)
+		case 'a':
+			valid = checkDigitIsUpper(part)
+		default:
+			valid = checkDigitIsAlnum(part)
+		}
+		if !valid {
+			return nil, checkDigitFormatErr("IBAN", s, fmt.Sprintf("BBAN position %d does not match %s structure", pos+1, iban[:2]))
+		}
+		pos += p.n
+	}
+	if iban[2:4] == "00" || iban[2:4] == "01" || iban[2:4] == "99" || ibanMod97(bban+iban[:4]) != 1 {
+		return nil, checkDigitMismatchErr("IBAN", s)
+	}
+	return &IBAN{CountryCode: iban[:2], CheckDigits: iban[2:4], BBAN: bban}, nil
+}
+
+func IBANIsValid(s string) bool {
+	_, err := IBANParse(s)
+	return err == nil
+}
+
+// IBANCountryLength returns the IBAN length of a country, or false if the
+// country does not use IBANs.
+func IBANCountryLength(country string) (int, bool) {
+	return IBANFormatTable().Length(country)
+}
+
+// ibanMod97 returns s mod 97 after replacing letters by 10 to 35.
+func ibanMod97(s string) int {
+	var digits strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			digits.WriteByte(c)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	return int(n.Mod(n, big.NewInt(97)).Int64())
+}
+
+// NewIBANFormats reads IBAN formats with one country per line: ISO 3166
+// country code, IBAN length and BBAN structure in the notation of the
+// SWIFT IBAN registry, e.g. "DE 22 8!n10!n".
+func NewIBANFormats(r io.Reader) (*IBANFormats, error) {
+	t := &IBANFormats{formats: make(map[string]ibanFormat)}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 || len(fields[0]) != 2 || !checkDigitIsUpper(fields[0]) {
+			return nil, fmt.Errorf("line %d: malformed entry", n)
+		}
+		length, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid length %q", n, fields[1])
+		}
+
+		format := ibanFormat{length: length}
+		total := 4
+		for spec := fields[2]; spec != ""; {
+			i := strings.IndexByte(spec, '!')
+			if i <= 0 || i+1 >= len(spec) || !strings.ContainsRune("nac", rune(spec[i+1])) {
+				return nil, fmt.Errorf("line %d: invalid structure %q", n, fields[2])
+			}
+			size, err := strconv.Atoi(spec[:i])
+			if err != nil || size < 1 {
+				return nil, fmt.Errorf("line %d: invalid structure %q", n, fields[2])
+			}
+			format.parts = append(format.parts, ibanPart{n: size, class: spec[i+1]})
+			total += size
+			spec = spec[i+2:]
+		}
+		if total != length {
+			return nil, fmt.Errorf("line %d: structure does not match length %d", n, length)
+		}
+		t.formats[fields[0]] = format
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *IBANFormats) Len() int {
+	return len(t.formats)
+}
+
+// Countries returns the sorted country codes of t.
+func (t *IBANFormats) Countries() []string {
+	countries := make([]string, 0, len(t.formats))
+	for country := range t.formats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// Length returns the IBAN length of a country, or false if t has no
+// format for it.
+func (t *IBANFormats) Length(country string) (int, bool) {
+	format, ok := t.formats[country]
+	return format.length, ok
+}
+
+// IBANFormatTable returns the formats used by the IBAN functions. The
+// embedded registry table is parsed on first use.
+func IBANFormatTable() *IBANFormats {
+	ibanFormatsOnce.Do(func() {
+		ibanFormats.CompareAndSwap(nil, mustIBANFormats(resource.IBANFormats))
+	})
+	return ibanFormats.Load().(*IBANFormats)
+}
+
+// IBANSetFormatTable atomically replaces the formats used by the IBAN
+// functions, e.g. with a newer registry release.
+func IBANSetFormatTable(t *IBANFormats) {
+	ibanFormats.Store(t)
+}
+
+func IBANLoadFormatTable(r io.Reader) error {
+	t, err := NewIBANFormats(r)
+	if err != nil {
+		return err
+	}
+	IBANSetFormatTable(t)
+	return nil
+}
+
+func IBANLoadFormatTableFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := IBANLoadFormatTable(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func mustIBANFormats(data string) *IBANFormats {
+	t, err := NewIBANFormats(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
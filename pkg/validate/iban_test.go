@@ -0,0 +1,230 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestIBANParse(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		country string
+		bban    string
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{name: "DE", in: "DE89370400440532013000", want: want{country: "DE", bban: "370400440532013000"}},
+		{name: "print format", in: "GB82 WEST 1234 5698 7654 32", want: want{country: "GB", bban: "WEST12345698765432"}},
+		{name: "lowercase", in: "nl91abna0417164300", want: want{country: "NL", bban: "ABNA0417164300"}},
+		{name: "FR alphanumeric", in: "FR1420041010050500013M02606", want: want{country: "FR", bban: "20041010050500013M02606"}},
+		{name: "NO shortest", in: "NO9386011117947", want: want{country: "NO", bban: "86011117947"}},
+		{name: "mismatch", in: "DE89370400440532013001", want: want{err: validate.ErrCheckDigitMismatch}},
+		{name: "wrong length", in: "DE8937040044053201300", want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "unknown country", in: "US89370400440532013000", want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "letters in numeric BBAN", in: "DE89370400440532O13000", want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "digits in bank code", in: "GB82WE5T12345698765432", want: want{err: validate.ErrCheckDigitFormat}},
+		{name: "empty", in: "", want: want{err: validate.ErrCheckDigitFormat}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.IBANParse(tt.in)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("IBANParse(%q) error = %v, want %v", tt.in, err, tt.want.err)
+			}
+			if err == nil && (got.CountryCode != tt.want.country || got.BBAN != tt.want.bban) {
+				t.Errorf("IBANParse(%q) = %+v, want %s %s", tt.in, *got, tt.want.country, tt.want.bban)
+			}
+		})
+	}
+
+	iban, _ := validate.IBANParse("DE89370400440532013000")
+	if got := iban.Print(); got != "DE89 3704 0044 0532 0130 00" {
+		t.Errorf("Print() = %q, want %q", got, "DE89 3704 0044 0532 0130 00")
+	}
+	if n, ok := validate.IBANCountryLength("MT"); !ok || n != 31 {
+		t.Errorf("IBANCountryLength(%q) = %d, %v, want 31, true", "MT", n, ok)
+	}
+}
+
+// TestIBANRegistryExamples parses the example IBAN of every country in the
+// SWIFT IBAN registry release named by the embedded table.
+func TestIBANRegistryExamples(t *testing.T) {
+	t.Parallel()
+
+	examples := []string{
+		"AD1200012030200359100100",
+		"AE070331234567890123456",
+		"AL47212110090000000235698741",
+		"AT611904300234573201",
+		"AZ21NABZ00000000137010001944",
+		"BA391290079401028494",
+		"BE68539007547034",
+		"BG80BNBG96611020345678",
+		"BH67BMAG00001299123456",
+		"BI4210000100010000332045181",
+		"BR1800360305000010009795493C1",
+		"BY13NBRB3600900000002Z00AB00",
+		"CH9300762011623852957",
+		"CR05015202001026284066",
+		"CY17002001280000001200527600",
+		"CZ6508000000192000145399",
+		"DE89370400440532013000",
+		"DJ2100010000000154000100186",
+		"DK5000400440116243",
+		"DO28BAGR00000001212453611324",
+		"EE382200221020145685",
+		"EG380019000500000000263180002",
+		"ES9121000418450200051332",
+		"FI2112345600000785",
+		"FK88SC123456789012",
+		"FO6264600001631634",
+		"FR1420041010050500013M02606",
+		"GB29NWBK60161331926819",
+		"GE29NB0000000101904917",
+		"GI75NWBK000000007099453",
+		"GL8964710001000206",
+		"GR1601101250000000012300695",
+		"GT82TRAJ01020000001210029690",
+		"HR1210010051863000160",
+		"HU42117730161111101800000000",
+		"IE29AIBK93115212345678",
+		"IL620108000000099999999",
+		"IQ98NBIQ850123456789012",
+		"IS140159260076545510730339",
+		"IT60X0542811101000000123456",
+		"JO94CBJO0010000000000131000302",
+		"KW81CBKU0000000000001234560101",
+		"KZ86125KZT5004100100",
+		"LB62099900000001001901229114",
+		"LC55HEMM000100010012001200023015",
+		"LI21088100002324013AA",
+		"LT121000011101001000",
+		"LU280019400644750000",
+		"LV80BANK0000435195001",
+		"LY83002048000020100120361",
+		"MC5811222000010123456789030",
+		"MD24AG000225100013104168",
+		"ME25505000012345678951",
+		"MK07250120000058984",
+		"MN121234123456789123",
+		"MR1300020001010000123456753",
+		"MT84MALT011000012345MTLCAST001S",
+		"MU17BOMM0101101030300200000MUR",
+		"NI45BAPR00000013000003558124",
+		"NL91ABNA0417164300",
+		"NO9386011117947",
+		"PK36SCBL0000001123456702",
+		"PL61109010140000071219812874",
+		"PS92PALS000000000400123456702",
+		"PT50000201231234567890154",
+		"QA58DOHB00001234567890ABCDEFG",
+		"RO49AAAA1B31007593840000",
+		"RS35260005601001611379",
+		"RU0204452560040702810412345678901",
+		"SA0380000000608010167519",
+		"SC18SSCB11010000000000001497USD",
+		"SD2129010501234001",
+		"SE4550000000058398257466",
+		"SI56263300012039086",
+		"SK3112000000198742637541",
+		"SM86U0322509800000000270100",
+		"SO211000001001000100141",
+		"ST68000100010051845310112",
+		"SV62CENR00000000000000700025",
+		"TL380080012345678910157",
+		"TN5910006035183598478831",
+		"TR330006100519786457841326",
+		"UA213223130000026007233566001",
+		"VA59001123000012345678",
+		"VG96VPVG0000012345678901",
+		"XK051212012345678906",
+	}
+
+	if got, want := len(examples), validate.IBANFormatTable().Len(); got != want {
+		t.Errorf("%d examples for %d countries", got, want)
+	}
+
+	for _, example := range examples {
+		example := example
+		t.Run(example[:2], func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.IBANParse(example)
+			if err != nil {
+				t.Fatalf("IBANParse(%q) error = %v", example, err)
+			}
+			if got.CountryCode != example[:2] || got.BBAN != example[4:] {
+				t.Errorf("IBANParse(%q) = %+v", example, *got)
+			}
+		})
+	}
+}
+
+func TestIBANFormatTable(t *testing.T) {
+	defer validate.IBANSetFormatTable(validate.IBANFormatTable())
+
+	if got := validate.IBANFormatTable().Countries(); len(got) == 0 || got[0] != "AD" {
+		t.Errorf("IBANFormatTable().Countries() = %q, want AD first", got)
+	}
+
+	err := validate.IBANLoadFormatTable(strings.NewReader("# release 99\nYE 30 4!a4!n18!c\nDE 22 8!n10!n\n"))
+	if err != nil {
+		t.Fatalf("IBANLoadFormatTable() error = %v", err)
+	}
+	if _, err := validate.IBANParse("YE15CBYE0001018861234567891234"); err != nil {
+		t.Errorf("IBANParse(YE) error = %v", err)
+	}
+	if _, err := validate.IBANParse("FR1420041010050500013M02606"); !errors.Is(err, validate.ErrCheckDigitFormat) {
+		t.Errorf("IBANParse(FR) error = %v, want %v", err, validate.ErrCheckDigitFormat)
+	}
+	if n, ok := validate.IBANCountryLength("YE"); !ok || n != 30 {
+		t.Errorf("IBANCountryLength(%q) = %d, %v, want 30, true", "YE", n, ok)
+	}
+
+	for _, data := range []string{
+		"DE 22",
+		"de 22 8!n10!n",
+		"DE 22 8!n10!x",
+		"DE 23 8!n10!n",
+		"DE x 8!n10!n",
+	} {
+		if _, err := validate.NewIBANFormats(strings.NewReader(data)); err == nil {
+			t.Errorf("NewIBANFormats(%q) error = nil", data)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLength   = 26
+
+	ksuidAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidLength   = 27
+	// ksuidMax is the largest 160-bit value in base62. Since the alphabet
+	// is in ASCII order, fixed-length strings compare like their values.
+	ksuidMax = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+	// ksuidEpoch is the start of KSUID time, 2014-05-13T16:53:20Z.
+	ksuidEpoch = 1400000000
+
+	NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	NanoIDLength   = 21
+)
+
+var (
+	ErrULIDInvalid      = errors.New("invalid ULID")
+	ErrKSUIDInvalid     = errors.New("invalid KSUID")
+	ErrSnowflakeInvalid = errors.New("invalid Snowflake ID")
+	ErrNanoIDInvalid    = errors.New("invalid NanoID")
+	ErrIDTimeRange      = errors.New("ID timestamp out of range")
+)
+
+var (
+	// TwitterSnowflake has a 41-bit millisecond timestamp, a 10-bit
+	// machine ID and a 12-bit sequence number.
+	TwitterSnowflake = SnowflakeLayout{Epoch: time.UnixMilli(1288834974657).UTC(), NodeBits: 10, SequenceBits: 12}
+	// DiscordSnowflake uses the Twitter layout with its own epoch.
+	DiscordSnowflake = SnowflakeLayout{Epoch: time.UnixMilli(1420070400000).UTC(), NodeBits: 10, SequenceBits: 12}
+)
+
+type ULID struct {
+	Time    time.Time
+	Entropy [10]byte
+}
+
+type KSUID struct {
+	Time    time.Time
+	Payload [16]byte
+}
+
+// SnowflakeLayout describes a Snowflake ID: a millisecond timestamp since
+// Epoch in the high bits, followed by NodeBits of machine ID and
+// SequenceBits of sequence number. The sign bit is always zero.
+type SnowflakeLayout struct {
+	Epoch        time.Time
+	NodeBits     uint
+	SequenceBits uint
+}
+
+type Snowflake struct {
+	ID       uint64
+	Time     time.Time
+	Node     uint64
+	Sequence uint64
+}
+
+// IDTimeRange restricts the timestamp embedded in an ID. Zero bounds are
+// not checked.
+type IDTimeRange struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+type NanoIDOptions struct {
+	// Alphabet defaults to NanoIDAlphabet.
+	Alphabet string
+	// Length defaults to NanoIDLength.
+	Length int
+}
+
+func init() {
+	RegisterRule("ulid", stringRuleFactory(ULIDIsValid, ErrULIDInvalid))
+	RegisterRule("ksuid", stringRuleFactory(KSUIDIsValid, ErrKSUIDInvalid))
+	RegisterRule("snowflake", stringRuleFactory(SnowflakeIsValid, ErrSnowflakeInvalid))
+	RegisterRule("nanoid", func(param string) (ValidationRule, error) {
+		opts := NanoIDOptions{}
+		if param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("%w: length %q", ErrNanoIDInvalid, param)
+			}
+			opts.Length = n
+		}
+		return StringRule(func(s string) bool { return NanoIDValidate(s, opts) == nil }, ErrNanoIDInvalid), nil
+	})
+}
+
+// ULIDParse parses a ULID: 26 characters of Crockford's base32 in either
+// case, encoding a 48-bit millisecond timestamp and 80 bits of entropy.
+func ULIDParse(s string) (*ULID, error) {
+	if len(s) != ulidLength {
+		return nil, fmt.Errorf("%w: length %d, want %d", ErrULIDInvalid, len(s), ulidLength)
+	}
+	// 26 characters hold 130 bits, so the first may be at most '7'.
+	if s[0] > '7' {
+		return nil, fmt.Errorf("%w: %q overflows 128 bits", ErrULIDInvalid, s)
+	}
+
+	// Shift the value into a 128-bit big-endian pair of words.
+	var v [2]uint64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(ulidAlphabet, asciiToUpper(s[i]))
+		if d < 0 {
+			return nil, fmt.Errorf("%w: invalid character %q", ErrULIDInvalid, s[i])
+		}
+		v[0] = v[0]<<5 | v[1]>>59
+		v[1] = v[1]<<5 | uint64(d)
+	}
+
+	u := &ULID{Time: time.UnixMilli(int64(v[0] >> 16)).UTC()}
+	u.Entropy[0] = byte(v[0] >> 8)
+	u.Entropy[1] = byte(v[0])
+	for i := 0; i < 8; i++ {
+		u.Entropy[2+i] = byte(v[1] >> (56 - 8*i))
+	}
+	return u, nil
+}
+
+func ULIDIsValid(s string) bool {
+	_, err := ULIDParse(s)
+	return err == nil
+}
+
+func ULIDValidate(s string, r IDTimeRange) error {
+	u, err := ULIDParse(s)
+	if err != nil {
+		return err
+	}
+	return r.check(u.Time)
+}
+
+// KSUIDParse parses a KSUID: 27 base62 characters encoding a 32-bit
+// timestamp in seconds since 2014-05-13 and a 128-bit payload.
+func KSUIDParse(s string) (*KSUID, error) {
+	if len(s) != ksuidLength {
+		return nil, fmt.Errorf("%w: length %d, want %d", ErrKSUIDInvalid, len(s), ksuidLength)
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(ksuidAlphabet)))
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(ksuidAlphabet, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("%w: invalid character %q", ErrKSUIDInvalid, s[i])
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(d)))
+	}
+	if s > ksuidMax {
+		return nil, fmt.Errorf("%w: %q overflows 160 bits", ErrKSUIDInvalid, s)
+	}
+
+	var b [20]byte
+	n.FillBytes(b[:])
+	k := &KSUID{}
+	ts := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	k.Time = time.Unix(int64(ts)+ksuidEpoch, 0).UTC()
+	copy(k.Payload[:], b[4:])
+	return k, nil
+}
+
+func KSUIDIsValid(s string) bool {
+	_, err := KSUIDParse(s)
+	return err == nil
+}
+
+func KSUIDValidate(s string, r IDTimeRange) error {
+	k, err := KSUIDParse(s)
+	if err != nil {
+		return err
+	}
+	return r.check(k.Time)
+}
+
+// SnowflakeParse parses a Snowflake ID in decimal.
+func SnowflakeParse(s string, layout SnowflakeLayout) (*Snowflake, error) {
+	if layout.NodeBits+layout.SequenceBits >= 63 {
+		return nil, fmt.Errorf("%w: layout leaves no timestamp bits", ErrSnowflakeInvalid)
+	}
+	if s == "" || len(s) > 1 && s[0] == '0' || strings.Trim(s, "0123456789") != "" {
+		return nil, fmt.Errorf("%w: %q is not a decimal number", ErrSnowflakeInvalid, s)
+	}
+	id, err := strconv.ParseUint(s, 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q overflows 63 bits", ErrSnowflakeInvalid, s)
+	}
+
+	shift := layout.NodeBits + layout.SequenceBits
+	ms := int64(id >> shift)
+	return &Snowflake{
+		ID:       id,
+		Time:     time.UnixMilli(layout.Epoch.UnixMilli() + ms).UTC(),
+		Node:     id >> layout.SequenceBits & (1<<layout.NodeBits - 1),
+		Sequence: id & (1<<layout.SequenceBits - 1),
+	}, nil
+}
+
+// SnowflakeIsValid checks if s is a Twitter Snowflake ID that is not
+// from the future.
+func SnowflakeIsValid(s string) bool {
+	return SnowflakeValidate(s, TwitterSnowflake, IDTimeRange{NotAfter: time.Now()}) == nil
+}
+
+func SnowflakeValidate(s string, layout SnowflakeLayout, r IDTimeRange) error {
+	sf, err := SnowflakeParse(s, layout)
+	if err != nil {
+		return err
+	}
+	return r.check(sf.Time)
+}
+
+// NanoIDIsValid checks if s is a NanoID with the default alphabet and
+// length.
+func NanoIDIsValid(s string) bool {
+	return NanoIDValidate(s, NanoIDOptions{}) == nil
+}
+
+func NanoIDValidate(s string, opts NanoIDOptions) error {
+	alphabet, length := opts.Alphabet, opts.Length
+	if alphabet == "" {
+		alphabet = NanoIDAlphabet
+	}
+	if length <= 0 {
+		length = NanoIDLength
+	}
+
+	if n := len([]rune(s)); n != length {
+		return fmt.Errorf("%w: length %d, want %d", ErrNanoIDInvalid, n, length)
+	}
+	for _, r := range s {
+		if !strings.ContainsRune(alphabet, r) {
+			return fmt.Errorf("%w: invalid character %q", ErrNanoIDInvalid, r)
+		}
+	}
+	return nil
+}
+
+func (r IDTimeRange) check(t time.Time) error {
+	if !r.NotBefore.IsZero() && t.Before(r.NotBefore) {
+		return fmt.Errorf("%w: %s is before %s", ErrIDTimeRange, t.Format(time.RFC3339), r.NotBefore.Format(time.RFC3339))
+	}
+	if !r.NotAfter.IsZero() && t.After(r.NotAfter) {
+		return fmt.Errorf("%w: %s is after %s", ErrIDTimeRange, t.Format(time.RFC3339), r.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func asciiToUpper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,321 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestULIDParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		time    time.Time
+		entropy string
+		err     bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid",
+			in:   in{s: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+			want: want{time: time.UnixMilli(1469922850259), entropy: "d6764c61efb99302bd5b"},
+		},
+		{
+			name: "lowercase",
+			in:   in{s: "01arz3ndektsv4rrffq69g5fav"},
+			want: want{time: time.UnixMilli(1469922850259), entropy: "d6764c61efb99302bd5b"},
+		},
+		{
+			name: "maximum",
+			in:   in{s: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+			want: want{time: time.UnixMilli(1<<48 - 1), entropy: "ffffffffffffffffffff"},
+		},
+		{
+			name: "overflow",
+			in:   in{s: "80000000000000000000000000"},
+			want: want{err: true},
+		},
+		{
+			name: "excluded letter",
+			in:   in{s: "01ARZ3NDEKTSV4RRFFQ69G5FAU"},
+			want: want{err: true},
+		},
+		{
+			name: "too short",
+			in:   in{s: "01ARZ3NDEKTSV4RRFFQ69G5FA"},
+			want: want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.ULIDParse(tt.in.s)
+			if tt.want.err {
+				if !errors.Is(err, validate.ErrULIDInvalid) {
+					t.Errorf("ULIDParse(%q) error = %v, want %v", tt.in.s, err, validate.ErrULIDInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ULIDParse(%q) error = %v", tt.in.s, err)
+			}
+			if !got.Time.Equal(tt.want.time) || hex.EncodeToString(got.Entropy[:]) != tt.want.entropy {
+				t.Errorf("ULIDParse(%q) = %v, %x, want %v, %s", tt.in.s, got.Time, got.Entropy, tt.want.time, tt.want.entropy)
+			}
+		})
+	}
+}
+
+func TestULIDValidate(t *testing.T) {
+	t.Parallel()
+
+	const id = "01ARZ3NDEKTSV4RRFFQ69G5FAV" // 2016-07-30
+	r := validate.IDTimeRange{NotBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := validate.ULIDValidate(id, r); !errors.Is(err, validate.ErrIDTimeRange) {
+		t.Errorf("ULIDValidate(%q) = %v, want %v", id, err, validate.ErrIDTimeRange)
+	}
+	r = validate.IDTimeRange{NotBefore: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), NotAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := validate.ULIDValidate(id, r); err != nil {
+		t.Errorf("ULIDValidate(%q) = %v, want nil", id, err)
+	}
+}
+
+func TestKSUIDParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		time    time.Time
+		payload string
+		err     bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid",
+			in:   in{s: "0ujtsYcgvSTl8PAuAdqWYSMnLOv"},
+			want: want{time: time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC), payload: "b5a1cd34b5f99d1154fb6853345c9735"},
+		},
+		{
+			name: "maximum",
+			in:   in{s: "aWgEPTl1tmebfsQzFP4bxwgy80V"},
+			want: want{time: time.Unix(1<<32-1+1400000000, 0), payload: "ffffffffffffffffffffffffffffffff"},
+		},
+		{
+			name: "overflow",
+			in:   in{s: "aWgEPTl1tmebfsQzFP4bxwgy80W"},
+			want: want{err: true},
+		},
+		{
+			name: "invalid character",
+			in:   in{s: "0ujtsYcgvSTl8PAuAdqWYSMnLO-"},
+			want: want{err: true},
+		},
+		{
+			name: "too long",
+			in:   in{s: "0ujtsYcgvSTl8PAuAdqWYSMnLOvv"},
+			want: want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.KSUIDParse(tt.in.s)
+			if tt.want.err {
+				if !errors.Is(err, validate.ErrKSUIDInvalid) {
+					t.Errorf("KSUIDParse(%q) error = %v, want %v", tt.in.s, err, validate.ErrKSUIDInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KSUIDParse(%q) error = %v", tt.in.s, err)
+			}
+			if !got.Time.Equal(tt.want.time) || hex.EncodeToString(got.Payload[:]) != tt.want.payload {
+				t.Errorf("KSUIDParse(%q) = %v, %x, want %v, %s", tt.in.s, got.Time, got.Payload, tt.want.time, tt.want.payload)
+			}
+		})
+	}
+}
+
+func TestSnowflakeParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s      string
+		layout validate.SnowflakeLayout
+	}
+
+	type want struct {
+		time     time.Time
+		node     uint64
+		sequence uint64
+		err      bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "twitter",
+			in:   in{s: "1609747329538134058", layout: validate.TwitterSnowflake},
+			want: want{time: time.Date(2023, 1, 2, 3, 4, 5, 678e6, time.UTC), node: 17, sequence: 42},
+		},
+		{
+			name: "discord",
+			in:   in{s: "175928847299117063", layout: validate.DiscordSnowflake},
+			want: want{time: time.Date(2016, 4, 30, 11, 18, 25, 796e6, time.UTC), node: 32, sequence: 7},
+		},
+		{
+			name: "custom layout",
+			in:   in{s: "1029", layout: validate.SnowflakeLayout{Epoch: time.Unix(0, 0), NodeBits: 2, SequenceBits: 8}},
+			want: want{time: time.UnixMilli(1), node: 0, sequence: 5},
+		},
+		{
+			name: "sign bit",
+			in:   in{s: "9223372036854775808", layout: validate.TwitterSnowflake},
+			want: want{err: true},
+		},
+		{
+			name: "leading zero",
+			in:   in{s: "01", layout: validate.TwitterSnowflake},
+			want: want{err: true},
+		},
+		{
+			name: "negative",
+			in:   in{s: "-1", layout: validate.TwitterSnowflake},
+			want: want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.SnowflakeParse(tt.in.s, tt.in.layout)
+			if tt.want.err {
+				if !errors.Is(err, validate.ErrSnowflakeInvalid) {
+					t.Errorf("SnowflakeParse(%q) error = %v, want %v", tt.in.s, err, validate.ErrSnowflakeInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SnowflakeParse(%q) error = %v", tt.in.s, err)
+			}
+			if !got.Time.Equal(tt.want.time) || got.Node != tt.want.node || got.Sequence != tt.want.sequence {
+				t.Errorf("SnowflakeParse(%q) = %+v, want %+v", tt.in.s, got, tt.want)
+			}
+		})
+	}
+
+	if validate.SnowflakeIsValid("9223372036854775807") {
+		t.Errorf("SnowflakeIsValid() accepted an ID from the future")
+	}
+}
+
+func TestNanoIDValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.NanoIDOptions
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "default",
+			in:   in{s: "V1StGXR8_Z5jdHi6B-myT"},
+			want: want{result: true},
+		},
+		{
+			name: "default too short",
+			in:   in{s: "V1StGXR8_Z5jdHi6B-my"},
+			want: want{result: false},
+		},
+		{
+			name: "default invalid character",
+			in:   in{s: "V1StGXR8.Z5jdHi6B-myT"},
+			want: want{result: false},
+		},
+		{
+			name: "custom alphabet",
+			in:   in{s: "4f90d13a42", opts: validate.NanoIDOptions{Alphabet: "0123456789abcdef", Length: 10}},
+			want: want{result: true},
+		},
+		{
+			name: "custom alphabet invalid character",
+			in:   in{s: "4f90d13a4g", opts: validate.NanoIDOptions{Alphabet: "0123456789abcdef", Length: 10}},
+			want: want{result: false},
+		},
+		{
+			name: "non-ascii alphabet",
+			in:   in{s: "αβγ", opts: validate.NanoIDOptions{Alphabet: "αβγδ", Length: 3}},
+			want: want{result: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.NanoIDValidate(tt.in.s, tt.in.opts)
+			if (err == nil) != tt.want.result {
+				t.Errorf("NanoIDValidate(%q) = %v, want %v", tt.in.s, err, tt.want.result)
+			}
+		})
+	}
+
+	v := validate.NewValidate()
+	if err := v.AddNamedRule("nanoid=10"); err != nil {
+		t.Fatalf("AddNamedRule() error = %v", err)
+	}
+	if err := v.Validate("V1StGXR8_Z"); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
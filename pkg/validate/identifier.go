@@ -0,0 +1,385 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+	"golang.org/x/text/unicode/norm"
+)
+
+// RestrictionLevel is a restriction level of Unicode Technical Standard
+// #39, section 5.2, ordered from the most to the least restrictive.
+type RestrictionLevel int
+
+const (
+	RestrictionASCII RestrictionLevel = iota + 1
+	RestrictionSingleScript
+	// RestrictionHighly also allows Latin mixed with the scripts of
+	// Chinese, Japanese or Korean writing.
+	RestrictionHighly
+	// RestrictionModerately also allows Latin mixed with one other
+	// recommended script except Cyrillic and Greek.
+	RestrictionModerately
+	RestrictionMinimally
+	// RestrictionUnrestricted is the level of strings with characters
+	// outside the identifier profile.
+	RestrictionUnrestricted
+)
+
+var restrictionLevelNames = []string{
+	RestrictionASCII:        "ascii",
+	RestrictionSingleScript: "single_script",
+	RestrictionHighly:       "highly_restrictive",
+	RestrictionModerately:   "moderately_restrictive",
+	RestrictionMinimally:    "minimally_restrictive",
+	RestrictionUnrestricted: "unrestricted",
+}
+
+var (
+	ErrIdentifierInvalid     = errors.New("invalid identifier")
+	ErrIdentifierRestriction = fmt.Errorf("%w: mixes scripts beyond the restriction level", ErrIdentifierInvalid)
+	ErrIdentifierInvisible   = fmt.Errorf("%w: invisible character", ErrIdentifierInvalid)
+	ErrIdentifierBidiControl = fmt.Errorf("%w: bidirectional control character", ErrIdentifierInvalid)
+	ErrRestrictionLevel      = errors.New("invalid restriction level")
+)
+
+type IdentifierOptions struct {
+	// MaxRestriction is the least restrictive level accepted and defaults
+	// to RestrictionModerately.
+	MaxRestriction RestrictionLevel
+	// AllowInvisible accepts default ignorable characters such as
+	// U+200B ZERO WIDTH SPACE.
+	AllowInvisible bool
+	// AllowBidiControl accepts bidirectional formatting characters.
+	AllowBidiControl bool
+}
+
+// Pseudo-scripts of UTS #39 for the combinations of Han with the scripts
+// used alongside it.
+const (
+	scriptHanb = "Han with Bopomofo"
+	scriptJpan = "Japanese"
+	scriptKore = "Korean"
+)
+
+// identifierRecommendedScripts are the scripts of UAX #31, table 5.
+var identifierRecommendedScripts = map[string]bool{
+	"Arabic": true, "Armenian": true, "Bengali": true, "Bopomofo": true,
+	"Cyrillic": true, "Devanagari": true, "Ethiopic": true, "Georgian": true,
+	"Greek": true, "Gujarati": true, "Gurmukhi": true, "Han": true,
+	"Hangul": true, "Hebrew": true, "Hiragana": true, "Kannada": true,
+	"Katakana": true, "Khmer": true, "Lao": true, "Latin": true,
+	"Malayalam": true, "Myanmar": true, "Oriya": true, "Sinhala": true,
+	"Tamil": true, "Telugu": true, "Thaana": true, "Thai": true,
+	"Tibetan": true, scriptHanb: true, scriptJpan: true, scriptKore: true,
+}
+
+var identifierAugmentedScripts = map[string][]string{
+	"Han":      {"Han", scriptHanb, scriptJpan, scriptKore},
+	"Hiragana": {"Hiragana", scriptJpan},
+	"Katakana": {"Katakana", scriptJpan},
+	"Hangul":   {"Hangul", scriptKore},
+	"Bopomofo": {"Bopomofo", scriptHanb},
+}
+
+var (
+	identifierScriptNames     []string
+	identifierScriptNamesOnce sync.Once
+
+	confusablesTable map[rune]string
+	confusablesOnce  sync.Once
+)
+
+func init() {
+	RegisterRule("identifier", stringRuleFactory(IdentifierIsValid, ErrIdentifierInvalid))
+	RegisterRule("restriction", func(param string) (ValidationRule, error) {
+		level, err := ParseRestrictionLevel(param)
+		if err != nil {
+			return nil, err
+		}
+		return StringRule(func(s string) bool {
+			return IdentifierRestrictionLevel(s) <= level
+		}, ErrIdentifierRestriction), nil
+	})
+	RegisterRule("no_invisible", stringRuleFactory(func(s string) bool {
+		return !StringHasInvisible(s)
+	}, ErrIdentifierInvisible))
+	RegisterRule("no_bidi_control", stringRuleFactory(func(s string) bool {
+		return !StringHasBidiControl(s)
+	}, ErrIdentifierBidiControl))
+}
+
+func (l RestrictionLevel) String() string {
+	if l > 0 && int(l) < len(restrictionLevelNames) {
+		return restrictionLevelNames[l]
+	}
+	return "RestrictionLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseRestrictionLevel parses the name of a restriction level as
+// returned by RestrictionLevel.String, e.g. "highly_restrictive".
+func ParseRestrictionLevel(s string) (RestrictionLevel, error) {
+	for l, name := range restrictionLevelNames {
+		if l > 0 && s == name {
+			return RestrictionLevel(l), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrRestrictionLevel, s)
+}
+
+// IdentifierIsValid checks if s is at most moderately restrictive and
+// contains no invisible or bidirectional control characters.
+func IdentifierIsValid(s string) bool {
+	return IdentifierValidate(s, IdentifierOptions{}) == nil
+}
+
+func IdentifierValidate(s string, opts IdentifierOptions) error {
+	limit := opts.MaxRestriction
+	if limit == 0 {
+		limit = RestrictionModerately
+	}
+
+	switch {
+	case s == "":
+		return fmt.Errorf("%w: empty", ErrIdentifierInvalid)
+	case !opts.AllowBidiControl && StringHasBidiControl(s):
+		return ErrIdentifierBidiControl
+	case !opts.AllowInvisible && StringHasInvisible(s):
+		return ErrIdentifierInvisible
+	}
+	if level := IdentifierRestrictionLevel(s); level > limit {
+		return fmt.Errorf("%w: %s, limit is %s", ErrIdentifierRestriction, level, limit)
+	}
+	return nil
+}
+
+// IdentifierIsMixedScript checks if no single script covers s, ignoring
+// characters shared between scripts such as digits.
+func IdentifierIsMixedScript(s string) bool {
+	scripts := identifierResolveScripts(s, false)
+	return scripts != nil && len(scripts) == 0
+}
+
+// IdentifierRestrictionLevel returns the most restrictive level s
+// satisfies. The identifier profile is approximated as letters, combining
+// marks, decimal digits and "-", ".", "_" and "'". Script_Extensions are
+// not taken into account, so characters shared by a few scripts are
+// treated as shared by all of them.
+func IdentifierRestrictionLevel(s string) RestrictionLevel {
+	if strings.IndexFunc(s, func(r rune) bool { return !identifierInProfile(r) }) >= 0 {
+		return RestrictionUnrestricted
+	}
+	if isASCII(s) {
+		return RestrictionASCII
+	}
+	if scripts := identifierResolveScripts(s, false); scripts == nil || len(scripts) > 0 {
+		return RestrictionSingleScript
+	}
+
+	// With Latin set aside, the rest must resolve to a single script.
+	scripts := identifierResolveScripts(s, true)
+	if len(scripts) == 0 {
+		return RestrictionMinimally
+	}
+	if scripts[scriptJpan] || scripts[scriptHanb] || scripts[scriptKore] {
+		return RestrictionHighly
+	}
+	for script := range scripts {
+		if identifierRecommendedScripts[script] && script != "Cyrillic" && script != "Greek" {
+			return RestrictionModerately
+		}
+	}
+	return RestrictionMinimally
+}
+
+// IdentifierSkeleton returns the UTS #39 skeleton of s. Strings with the
+// same skeleton are visually confusable, e.g. "paypal" and "pаypal" with
+// a Cyrillic "а". Skeletons are meant for comparison only and are not
+// case folded.
+func IdentifierSkeleton(s string) string {
+	table := confusables()
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if t, ok := table[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFD.String(b.String())
+}
+
+func IdentifierIsConfusable(a, b string) bool {
+	return IdentifierSkeleton(a) == IdentifierSkeleton(b)
+}
+
+// StringHasInvisible checks if s contains default ignorable code points,
+// which are not rendered, such as U+200B ZERO WIDTH SPACE, U+2060 WORD
+// JOINER, variation selectors and Hangul fillers.
+func StringHasInvisible(s string) bool {
+	return strings.IndexFunc(s, identifierIsDefaultIgnorable) >= 0
+}
+
+// StringHasBidiControl checks if s contains bidirectional formatting
+// characters, which can reorder how the rest of a string is displayed.
+func StringHasBidiControl(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Bidi_Control, r)
+	}) >= 0
+}
+
+// identifierResolveScripts returns the resolved script set of s. A nil set
+// means every script, which is the case when s has only Common and
+// Inherited characters. With skipLatin, Latin characters are ignored.
+func identifierResolveScripts(s string, skipLatin bool) map[string]bool {
+	var resolved map[string]bool
+	for _, r := range s {
+		scripts := identifierScriptsOf(r)
+		if scripts == nil || skipLatin && scripts[0] == "Latin" {
+			continue
+		}
+		if resolved == nil {
+			resolved = make(map[string]bool, len(scripts))
+			for _, script := range scripts {
+				resolved[script] = true
+			}
+			continue
+		}
+		for script := range resolved {
+			if !containsString(scripts, script) {
+				delete(resolved, script)
+			}
+		}
+	}
+	return resolved
+}
+
+// identifierScriptsOf returns the augmented script set of r, or nil for
+// Common and Inherited characters.
+func identifierScriptsOf(r rune) []string {
+	if r < utf8.RuneSelf && !unicode.IsLetter(r) || unicode.In(r, unicode.Common, unicode.Inherited) {
+		return nil
+	}
+	if unicode.Is(unicode.Latin, r) {
+		return []string{"Latin"}
+	}
+
+	identifierScriptNamesOnce.Do(func() {
+		for name := range unicode.Scripts {
+			if name != "Common" && name != "Inherited" && name != "Latin" {
+				identifierScriptNames = append(identifierScriptNames, name)
+			}
+		}
+		sort.Strings(identifierScriptNames)
+	})
+	for _, name := range identifierScriptNames {
+		if unicode.Is(unicode.Scripts[name], r) {
+			if augmented, ok := identifierAugmentedScripts[name]; ok {
+				return augmented
+			}
+			return []string{name}
+		}
+	}
+	// Unassigned code points have the Unknown script.
+	return []string{"Unknown"}
+}
+
+func identifierInProfile(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) {
+		return !identifierIsDefaultIgnorable(r)
+	}
+	return r == '-' || r == '.' || r == '_' || r == '\''
+}
+
+// identifierIsDefaultIgnorable approximates the derived
+// Default_Ignorable_Code_Point property from the tables of package unicode.
+func identifierIsDefaultIgnorable(r rune) bool {
+	switch {
+	case unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r),
+		unicode.Is(unicode.Variation_Selector, r):
+		return true
+	case r >= 0xFFF9 && r <= 0xFFFB, r >= 0x13430 && r <= 0x1343F:
+		return false
+	}
+	return unicode.Is(unicode.Cf, r) && !unicode.Is(unicode.Prepended_Concatenation_Mark, r)
+}
+
+func confusables() map[rune]string {
+	confusablesOnce.Do(func() {
+		table, err := parseConfusables(resource.Confusables)
+		if err != nil {
+			panic(err)
+		}
+		confusablesTable = table
+	})
+	return confusablesTable
+}
+
+// parseConfusables parses lines of the form "0430 ; 0061 ; MA # comment".
+func parseConfusables(data string) (map[rune]string, error) {
+	table := make(map[rune]string)
+	for n, line := range strings.Split(data, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("confusables line %d: malformed mapping", n+1)
+		}
+		src, err := parseCodePoints(fields[0])
+		if err != nil || len(src) != 1 {
+			return nil, fmt.Errorf("confusables line %d: invalid source %q", n+1, fields[0])
+		}
+		target, err := parseCodePoints(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("confusables line %d: %w", n+1, err)
+		}
+		table[src[0]] = string(target)
+	}
+	return table, nil
+}
+
+func parseCodePoints(s string) ([]rune, error) {
+	fields := strings.Fields(s)
+	runes := make([]rune, 0, len(fields))
+	for _, f := range fields {
+		n, err := strconv.ParseUint(f, 16, 21)
+		if err != nil || !utf8.ValidRune(rune(n)) {
+			return nil, fmt.Errorf("invalid code point %q", f)
+		}
+		runes = append(runes, rune(n))
+	}
+	return runes, nil
+}
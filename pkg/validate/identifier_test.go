@@ -0,0 +1,402 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestIdentifierRestrictionLevel(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		level validate.RestrictionLevel
+		mixed bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ascii",
+			in:   in{s: "paypal"},
+			want: want{level: validate.RestrictionASCII},
+		},
+		{
+			name: "latin with accent",
+			in:   in{s: "café"},
+			want: want{level: validate.RestrictionSingleScript},
+		},
+		{
+			name: "cyrillic",
+			in:   in{s: "привет"},
+			want: want{level: validate.RestrictionSingleScript},
+		},
+		{
+			name: "cyrillic with digits",
+			in:   in{s: "привет_42"},
+			want: want{level: validate.RestrictionSingleScript},
+		},
+		{
+			name: "latin with japanese",
+			in:   in{s: "abc漢字ひらがなカタカナ"},
+			want: want{level: validate.RestrictionHighly, mixed: true},
+		},
+		{
+			name: "han and hiragana",
+			in:   in{s: "漢字ひらがな"},
+			want: want{level: validate.RestrictionSingleScript},
+		},
+		{
+			name: "latin with hangul",
+			in:   in{s: "abc한글"},
+			want: want{level: validate.RestrictionHighly, mixed: true},
+		},
+		{
+			name: "latin with arabic",
+			in:   in{s: "abcعربي"},
+			want: want{level: validate.RestrictionModerately, mixed: true},
+		},
+		{
+			name: "latin with cyrillic",
+			in:   in{s: "p\u0430ypal"},
+			want: want{level: validate.RestrictionMinimally, mixed: true},
+		},
+		{
+			name: "latin with greek",
+			in:   in{s: "\u03b1bc"},
+			want: want{level: validate.RestrictionMinimally, mixed: true},
+		},
+		{
+			name: "latin with arabic and hebrew",
+			in:   in{s: "abcعא"},
+			want: want{level: validate.RestrictionMinimally, mixed: true},
+		},
+		{
+			name: "space",
+			in:   in{s: "pay pal"},
+			want: want{level: validate.RestrictionUnrestricted},
+		},
+		{
+			name: "zero width space",
+			in:   in{s: "pay\u200bpal"},
+			want: want{level: validate.RestrictionUnrestricted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.IdentifierRestrictionLevel(tt.in.s); got != tt.want.level {
+				t.Errorf("IdentifierRestrictionLevel(%q) = %v, want %v", tt.in.s, got, tt.want.level)
+			}
+			if got := validate.IdentifierIsMixedScript(tt.in.s); got != tt.want.mixed {
+				t.Errorf("IdentifierIsMixedScript(%q) = %v, want %v", tt.in.s, got, tt.want.mixed)
+			}
+		})
+	}
+}
+
+func TestIdentifierSkeleton(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		a string
+		b string
+	}
+
+	type want struct {
+		confusable bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "cyrillic a",
+			in:   in{a: "paypal", b: "p\u0430ypal"},
+			want: want{confusable: true},
+		},
+		{
+			name: "greek omicron",
+			in:   in{a: "google", b: "g\u03bfogle"},
+			want: want{confusable: true},
+		},
+		{
+			name: "digit one and letter l",
+			in:   in{a: "paypal", b: "paypa1"},
+			want: want{confusable: true},
+		},
+		{
+			name: "capital i and letter l",
+			in:   in{a: "Illinois", b: "llIinois"},
+			want: want{confusable: true},
+		},
+		{
+			name: "rn and m",
+			in:   in{a: "modern", b: "rnodern"},
+			want: want{confusable: true},
+		},
+		{
+			name: "fullwidth is not folded",
+			in:   in{a: "admin", b: "ａｄｍｉｎ"},
+			want: want{confusable: false},
+		},
+		{
+			name: "mathematical bold",
+			in:   in{a: "paypal", b: "\U0001D429\U0001D41Ayp\U0001D41Al"},
+			want: want{confusable: true},
+		},
+		{
+			name: "roman numeral",
+			in:   in{a: "IV", b: "\u2163"},
+			want: want{confusable: true},
+		},
+		{
+			name: "cherokee",
+			in:   in{a: "AWS", b: "\u13AAWS"},
+			want: want{confusable: true},
+		},
+		{
+			name: "devanagari digit zero",
+			in:   in{a: "google", b: "g\u0966\u0966gle"},
+			want: want{confusable: true},
+		},
+		{
+			name: "hebrew vav",
+			in:   in{a: "pal", b: "pa\u05D5"},
+			want: want{confusable: true},
+		},
+		{
+			name: "minus sign",
+			in:   in{a: "a-b", b: "a\u2212b"},
+			want: want{confusable: true},
+		},
+		{
+			name: "arabic-indic digits",
+			in:   in{a: "\u0663", b: "\u06F3"},
+			want: want{confusable: true},
+		},
+		{
+			name: "bengali and gurmukhi digits",
+			in:   in{a: "\u09EA", b: "\u0A6A"},
+			want: want{confusable: true},
+		},
+		{
+			name: "katakana and han",
+			in:   in{a: "\u30ED", b: "\u53E3"},
+			want: want{confusable: true},
+		},
+		{
+			name: "decomposed accent",
+			in:   in{a: "café", b: "cafe\u0301"},
+			want: want{confusable: true},
+		},
+		{
+			name: "different",
+			in:   in{a: "paypal", b: "paypai"},
+			want: want{confusable: false},
+		},
+		{
+			name: "case",
+			in:   in{a: "paypal", b: "PayPal"},
+			want: want{confusable: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validate.IdentifierIsConfusable(tt.in.a, tt.in.b)
+			if got != tt.want.confusable {
+				t.Errorf("IdentifierIsConfusable(%q, %q) = %v, want %v (skeletons %q, %q)", tt.in.a, tt.in.b, got, tt.want.confusable,
+					validate.IdentifierSkeleton(tt.in.a), validate.IdentifierSkeleton(tt.in.b))
+			}
+		})
+	}
+}
+
+func TestIdentifierValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.IdentifierOptions
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ascii",
+			in:   in{s: "john.doe"},
+			want: want{err: nil},
+		},
+		{
+			name: "mixed latin and cyrillic",
+			in:   in{s: "p\u0430ypal"},
+			want: want{err: validate.ErrIdentifierRestriction},
+		},
+		{
+			name: "mixed latin and cyrillic allowed",
+			in:   in{s: "p\u0430ypal", opts: validate.IdentifierOptions{MaxRestriction: validate.RestrictionMinimally}},
+			want: want{err: nil},
+		},
+		{
+			name: "single script required",
+			in:   in{s: "abcعربي", opts: validate.IdentifierOptions{MaxRestriction: validate.RestrictionSingleScript}},
+			want: want{err: validate.ErrIdentifierRestriction},
+		},
+		{
+			name: "zero width joiner",
+			in:   in{s: "pay\u200dpal"},
+			want: want{err: validate.ErrIdentifierInvisible},
+		},
+		{
+			name: "right-to-left override",
+			in:   in{s: "invoice\u202efdp.exe"},
+			want: want{err: validate.ErrIdentifierBidiControl},
+		},
+		{
+			name: "empty",
+			in:   in{s: ""},
+			want: want{err: validate.ErrIdentifierInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.IdentifierValidate(tt.in.s, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("IdentifierValidate(%q) = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestStringHasInvisible(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		invisible bool
+		bidi      bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "plain",
+			in:   in{s: "hello world"},
+			want: want{invisible: false, bidi: false},
+		},
+		{
+			name: "zero width space",
+			in:   in{s: "hello\u200bworld"},
+			want: want{invisible: true, bidi: false},
+		},
+		{
+			name: "soft hyphen",
+			in:   in{s: "hel\u00adlo"},
+			want: want{invisible: true, bidi: false},
+		},
+		{
+			name: "hangul filler",
+			in:   in{s: "\u3164"},
+			want: want{invisible: true, bidi: false},
+		},
+		{
+			name: "variation selector",
+			in:   in{s: "❤\ufe0f"},
+			want: want{invisible: true, bidi: false},
+		},
+		{
+			name: "right-to-left mark",
+			in:   in{s: "abc\u200f"},
+			want: want{invisible: true, bidi: true},
+		},
+		{
+			name: "isolate",
+			in:   in{s: "\u2067abc\u2069"},
+			want: want{invisible: true, bidi: true},
+		},
+		{
+			name: "arabic number sign",
+			in:   in{s: "\u0600123"},
+			want: want{invisible: false, bidi: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.StringHasInvisible(tt.in.s); got != tt.want.invisible {
+				t.Errorf("StringHasInvisible(%q) = %v, want %v", tt.in.s, got, tt.want.invisible)
+			}
+			if got := validate.StringHasBidiControl(tt.in.s); got != tt.want.bidi {
+				t.Errorf("StringHasBidiControl(%q) = %v, want %v", tt.in.s, got, tt.want.bidi)
+			}
+		})
+	}
+}
+
+func TestIdentifierRules(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Username string `validate:"identifier"`
+		Display  string `validate:"restriction=single_script,no_bidi_control"`
+	}
+
+	err := validate.ValidateStruct(Account{Username: "p\u0430ypal", Display: "abc\u202e"})
+	if !errors.Is(err, validate.ErrIdentifierRestriction) || !errors.Is(err, validate.ErrIdentifierBidiControl) {
+		t.Errorf("ValidateStruct() = %v, want %v and %v", err, validate.ErrIdentifierRestriction, validate.ErrIdentifierBidiControl)
+	}
+	if err := validate.ValidateStruct(Account{Username: "paypal", Display: "привет"}); err != nil {
+		t.Errorf("ValidateStruct() = %v, want nil", err)
+	}
+
+	if _, err := validate.ParseRestrictionLevel("strict"); !errors.Is(err, validate.ErrRestrictionLevel) {
+		t.Errorf("ParseRestrictionLevel(strict) error = %v, want %v", err, validate.ErrRestrictionLevel)
+	}
+}
@@ -0,0 +1,243 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+var (
+	ErrIPInvalid      = errors.New("invalid IP address")
+	ErrIPv4Invalid    = errors.New("invalid IPv4 address")
+	ErrIPv6Invalid    = errors.New("invalid IPv6 address")
+	ErrIPNotCanonical = errors.New("IP address is not in canonical form")
+	ErrIPNotInRange   = errors.New("IP address is not in an allowed range")
+	ErrIPNotPrivate   = errors.New("IP address is not private")
+	ErrIPNotGlobal    = errors.New("IP address is not a global unicast address")
+	ErrCIDRInvalid    = errors.New("invalid CIDR prefix")
+	ErrIPRangeInvalid = errors.New("invalid IP range")
+)
+
+// ipSpecialPurposePrefixes are the special-purpose blocks from the IANA
+// IPv4 and IPv6 registries that are not globally reachable.
+var ipSpecialPurposePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),      // RFC 1918
+	netip.MustParsePrefix("100.64.0.0/10"),   // CGNAT, RFC 6598
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // RFC 1918
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // RFC 1918
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, broadcast
+	netip.MustParsePrefix("::/128"),          // unspecified
+	netip.MustParsePrefix("::1/128"),         // loopback
+	netip.MustParsePrefix("::ffff:0:0/96"),   // IPv4-mapped
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/32"),       // Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("fec0::/10"),       // site-local, deprecated
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+var ipDocumentationPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+func init() {
+	RegisterRule("ip", stringRuleFactory(IPIsValid, ErrIPInvalid))
+	RegisterRule("ipv4", stringRuleFactory(IPv4IsValid, ErrIPv4Invalid))
+	RegisterRule("ipv6", stringRuleFactory(IPv6IsValid, ErrIPv6Invalid))
+	RegisterRule("ip_canonical", stringRuleFactory(IPIsCanonical, ErrIPNotCanonical))
+	RegisterRule("ip_private", stringRuleFactory(IPIsPrivate, ErrIPNotPrivate))
+	RegisterRule("ip_global", stringRuleFactory(IPIsGlobalUnicast, ErrIPNotGlobal))
+	RegisterRule("cidr", stringRuleFactory(CIDRIsValid, ErrCIDRInvalid))
+	RegisterRule("ip_range", stringRuleFactory(IPRangeIsValid, ErrIPRangeInvalid))
+	RegisterRule("ip_in", func(param string) (ValidationRule, error) {
+		cidrs := strings.Split(param, "|")
+		for _, c := range cidrs {
+			if !CIDRIsValid(c) {
+				return nil, fmt.Errorf("%w: %q", ErrCIDRInvalid, c)
+			}
+		}
+		return StringRule(func(s string) bool { return IPInCIDRs(s, cidrs) }, ErrIPNotInRange), nil
+	})
+}
+
+// IPIsValid checks if s is an IPv4 address in dotted-decimal form or an
+// IPv6 address, optionally with a zone.
+func IPIsValid(s string) bool {
+	_, err := netip.ParseAddr(s)
+	return err == nil
+}
+
+// IPv4IsValid checks if s is an IPv4 address in dotted-decimal form.
+// Octets with leading zeros are rejected since they are ambiguous.
+func IPv4IsValid(s string) bool {
+	ip, err := netip.ParseAddr(s)
+	return err == nil && ip.Is4()
+}
+
+// IPv6IsValid checks if s is an IPv6 address, including addresses with a
+// zone such as "fe80::1%eth0" and IPv4-mapped addresses.
+func IPv6IsValid(s string) bool {
+	ip, err := netip.ParseAddr(s)
+	return err == nil && ip.Is6()
+}
+
+// IPIsCanonical checks if s is a valid IP address in its canonical text
+// form: dotted-decimal for IPv4 and RFC 5952 for IPv6.
+func IPIsCanonical(s string) bool {
+	ip, err := netip.ParseAddr(s)
+	return err == nil && ip.String() == s
+}
+
+// CIDRIsValid checks if s is a prefix in CIDR notation, e.g. "10.0.0.0/8".
+func CIDRIsValid(s string) bool {
+	_, err := netip.ParsePrefix(s)
+	return err == nil
+}
+
+// CIDRIsCanonical checks if s is a valid CIDR prefix in canonical form,
+// without host bits set.
+func CIDRIsCanonical(s string) bool {
+	p, err := netip.ParsePrefix(s)
+	return err == nil && p.Masked() == p && p.String() == s
+}
+
+// IPInCIDRs checks if the IP address s is contained in one of cidrs.
+// Invalid prefixes never match.
+func IPInCIDRs(s string, cidrs []string) bool {
+	ip, ok := ipParse(s)
+	if !ok {
+		return false
+	}
+	for _, c := range cidrs {
+		p, err := netip.ParsePrefix(c)
+		if err == nil && p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPRangeIsValid checks if s is a range of the form "a-b" where a and b
+// are addresses of the same family and a <= b.
+func IPRangeIsValid(s string) bool {
+	_, _, err := ipParseRange(s)
+	return err == nil
+}
+
+// IPRangeContains checks if the IP address ip is within the range r.
+func IPRangeContains(r string, ip string) bool {
+	from, to, err := ipParseRange(r)
+	if err != nil {
+		return false
+	}
+	addr, ok := ipParse(ip)
+	return ok && addr.BitLen() == from.BitLen() && addr.Compare(from) >= 0 && addr.Compare(to) <= 0
+}
+
+func IPIsLoopback(s string) bool {
+	ip, ok := ipParse(s)
+	return ok && ip.IsLoopback()
+}
+
+// IPIsPrivate checks if s is in an RFC 1918 IPv4 or RFC 4193 IPv6
+// unique local range.
+func IPIsPrivate(s string) bool {
+	ip, ok := ipParse(s)
+	return ok && ip.IsPrivate()
+}
+
+// IPIsGlobalUnicast checks if s is a globally reachable unicast address,
+// i.e. not within any special-purpose range such as private, CGNAT,
+// documentation or NAT64 networks.
+func IPIsGlobalUnicast(s string) bool {
+	ip, ok := ipParse(s)
+	return ok && ip.IsGlobalUnicast() && !ipInPrefixes(ip, ipSpecialPurposePrefixes)
+}
+
+// IPIsDocumentation checks if s is reserved for documentation
+// (RFC 5737 and RFC 3849).
+func IPIsDocumentation(s string) bool {
+	ip, ok := ipParse(s)
+	return ok && ipInPrefixes(ip, ipDocumentationPrefixes)
+}
+
+func IPIsMulticast(s string) bool {
+	ip, ok := ipParse(s)
+	return ok && ip.IsMulticast()
+}
+
+// ipParse parses s and drops any zone, which does not affect the class
+// of an address.
+func ipParse(s string) (netip.Addr, bool) {
+	ip, err := netip.ParseAddr(s)
+	return ip.WithZone(""), err == nil
+}
+
+func ipParseRange(s string) (netip.Addr, netip.Addr, error) {
+	a, b, ok := strings.Cut(s, "-")
+	if !ok {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: missing '-'", ErrIPRangeInvalid)
+	}
+	from, err := netip.ParseAddr(strings.TrimSpace(a))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %v", ErrIPRangeInvalid, err)
+	}
+	to, err := netip.ParseAddr(strings.TrimSpace(b))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %v", ErrIPRangeInvalid, err)
+	}
+	if from.BitLen() != to.BitLen() || from.Zone() != "" || to.Zone() != "" || from.Compare(to) > 0 {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %q", ErrIPRangeInvalid, s)
+	}
+	return from, to, nil
+}
+
+func ipInPrefixes(ip netip.Addr, prefixes []netip.Prefix) bool {
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,425 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestIPIsValid(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		ip        bool
+		ipv4      bool
+		ipv6      bool
+		canonical bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ipv4",
+			in: in{
+				s: "192.168.1.1",
+			},
+			want: want{ip: true, ipv4: true, ipv6: false, canonical: true},
+		},
+		{
+			name: "ipv4 with leading zeros",
+			in: in{
+				s: "192.168.001.001",
+			},
+			want: want{ip: false, ipv4: false, ipv6: false, canonical: false},
+		},
+		{
+			name: "ipv4 out of range",
+			in: in{
+				s: "256.1.1.1",
+			},
+			want: want{ip: false, ipv4: false, ipv6: false, canonical: false},
+		},
+		{
+			name: "ipv6",
+			in: in{
+				s: "2001:db8::1",
+			},
+			want: want{ip: true, ipv4: false, ipv6: true, canonical: true},
+		},
+		{
+			name: "ipv6 not compressed",
+			in: in{
+				s: "2001:DB8:0:0:0:0:0:1",
+			},
+			want: want{ip: true, ipv4: false, ipv6: true, canonical: false},
+		},
+		{
+			name: "ipv6 with zone",
+			in: in{
+				s: "fe80::1%eth0",
+			},
+			want: want{ip: true, ipv4: false, ipv6: true, canonical: true},
+		},
+		{
+			name: "ipv4-mapped ipv6",
+			in: in{
+				s: "::ffff:192.0.2.1",
+			},
+			want: want{ip: true, ipv4: false, ipv6: true, canonical: true},
+		},
+		{
+			name: "hostname",
+			in: in{
+				s: "example.com",
+			},
+			want: want{ip: false, ipv4: false, ipv6: false, canonical: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.IPIsValid(tt.in.s); got != tt.want.ip {
+				t.Errorf("IPIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.ip)
+			}
+			if got := validate.IPv4IsValid(tt.in.s); got != tt.want.ipv4 {
+				t.Errorf("IPv4IsValid(%q) = %v, want %v", tt.in.s, got, tt.want.ipv4)
+			}
+			if got := validate.IPv6IsValid(tt.in.s); got != tt.want.ipv6 {
+				t.Errorf("IPv6IsValid(%q) = %v, want %v", tt.in.s, got, tt.want.ipv6)
+			}
+			if got := validate.IPIsCanonical(tt.in.s); got != tt.want.canonical {
+				t.Errorf("IPIsCanonical(%q) = %v, want %v", tt.in.s, got, tt.want.canonical)
+			}
+		})
+	}
+}
+
+func TestCIDRIsValid(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		valid     bool
+		canonical bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ipv4 prefix",
+			in: in{
+				s: "10.0.0.0/8",
+			},
+			want: want{valid: true, canonical: true},
+		},
+		{
+			name: "host bits set",
+			in: in{
+				s: "10.1.2.3/8",
+			},
+			want: want{valid: true, canonical: false},
+		},
+		{
+			name: "ipv6 prefix",
+			in: in{
+				s: "2001:db8::/32",
+			},
+			want: want{valid: true, canonical: true},
+		},
+		{
+			name: "prefix too long",
+			in: in{
+				s: "10.0.0.0/33",
+			},
+			want: want{valid: false, canonical: false},
+		},
+		{
+			name: "missing length",
+			in: in{
+				s: "10.0.0.0",
+			},
+			want: want{valid: false, canonical: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.CIDRIsValid(tt.in.s); got != tt.want.valid {
+				t.Errorf("CIDRIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.valid)
+			}
+			if got := validate.CIDRIsCanonical(tt.in.s); got != tt.want.canonical {
+				t.Errorf("CIDRIsCanonical(%q) = %v, want %v", tt.in.s, got, tt.want.canonical)
+			}
+		})
+	}
+}
+
+func TestIPInCIDRs(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		ip    string
+		cidrs []string
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "in second prefix",
+			in: in{
+				ip:    "192.168.1.10",
+				cidrs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			},
+			want: want{result: true},
+		},
+		{
+			name: "in no prefix",
+			in: in{
+				ip:    "172.16.0.1",
+				cidrs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			},
+			want: want{result: false},
+		},
+		{
+			name: "ipv6 with zone",
+			in: in{
+				ip:    "fe80::1%eth0",
+				cidrs: []string{"fe80::/10"},
+			},
+			want: want{result: true},
+		},
+		{
+			name: "invalid prefix ignored",
+			in: in{
+				ip:    "10.0.0.1",
+				cidrs: []string{"10.0.0.0"},
+			},
+			want: want{result: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validate.IPInCIDRs(tt.in.ip, tt.in.cidrs)
+			if got != tt.want.result {
+				t.Errorf("IPInCIDRs(%q, %v) = %v, want %v", tt.in.ip, tt.in.cidrs, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestIPRange(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		r  string
+		ip string
+	}
+
+	type want struct {
+		valid    bool
+		contains bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ipv4 range",
+			in: in{
+				r:  "10.0.0.1-10.0.0.100",
+				ip: "10.0.0.50",
+			},
+			want: want{valid: true, contains: true},
+		},
+		{
+			name: "outside range",
+			in: in{
+				r:  "10.0.0.1-10.0.0.100",
+				ip: "10.0.0.101",
+			},
+			want: want{valid: true, contains: false},
+		},
+		{
+			name: "ipv6 range",
+			in: in{
+				r:  "2001:db8::1 - 2001:db8::ff",
+				ip: "2001:db8::a",
+			},
+			want: want{valid: true, contains: true},
+		},
+		{
+			name: "reversed range",
+			in: in{
+				r:  "10.0.0.100-10.0.0.1",
+				ip: "10.0.0.50",
+			},
+			want: want{valid: false, contains: false},
+		},
+		{
+			name: "mixed families",
+			in: in{
+				r:  "10.0.0.1-2001:db8::1",
+				ip: "10.0.0.50",
+			},
+			want: want{valid: false, contains: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.IPRangeIsValid(tt.in.r); got != tt.want.valid {
+				t.Errorf("IPRangeIsValid(%q) = %v, want %v", tt.in.r, got, tt.want.valid)
+			}
+			if got := validate.IPRangeContains(tt.in.r, tt.in.ip); got != tt.want.contains {
+				t.Errorf("IPRangeContains(%q, %q) = %v, want %v", tt.in.r, tt.in.ip, got, tt.want.contains)
+			}
+		})
+	}
+}
+
+func TestIPClassification(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		private       bool
+		global        bool
+		documentation bool
+		loopback      bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "rfc 1918",
+			in: in{
+				s: "172.16.5.4",
+			},
+			want: want{private: true},
+		},
+		{
+			name: "unique local",
+			in: in{
+				s: "fd12:3456::1",
+			},
+			want: want{private: true},
+		},
+		{
+			name: "public ipv4",
+			in: in{
+				s: "8.8.8.8",
+			},
+			want: want{global: true},
+		},
+		{
+			name: "public ipv6",
+			in: in{
+				s: "2606:4700:4700::1111",
+			},
+			want: want{global: true},
+		},
+		{
+			name: "cgnat",
+			in: in{
+				s: "100.64.1.1",
+			},
+			want: want{},
+		},
+		{
+			name: "documentation ipv4",
+			in: in{
+				s: "198.51.100.7",
+			},
+			want: want{documentation: true},
+		},
+		{
+			name: "documentation ipv6",
+			in: in{
+				s: "2001:db8::1",
+			},
+			want: want{documentation: true},
+		},
+		{
+			name: "loopback",
+			in: in{
+				s: "::1",
+			},
+			want: want{loopback: true},
+		},
+		{
+			name: "invalid",
+			in: in{
+				s: "not-an-ip",
+			},
+			want: want{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.IPIsPrivate(tt.in.s); got != tt.want.private {
+				t.Errorf("IPIsPrivate(%q) = %v, want %v", tt.in.s, got, tt.want.private)
+			}
+			if got := validate.IPIsGlobalUnicast(tt.in.s); got != tt.want.global {
+				t.Errorf("IPIsGlobalUnicast(%q) = %v, want %v", tt.in.s, got, tt.want.global)
+			}
+			if got := validate.IPIsDocumentation(tt.in.s); got != tt.want.documentation {
+				t.Errorf("IPIsDocumentation(%q) = %v, want %v", tt.in.s, got, tt.want.documentation)
+			}
+			if got := validate.IPIsLoopback(tt.in.s); got != tt.want.loopback {
+				t.Errorf("IPIsLoopback(%q) = %v, want %v", tt.in.s, got, tt.want.loopback)
+			}
+		})
+	}
+}
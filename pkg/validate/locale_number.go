@@ -0,0 +1,539 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/progxeno/validate/internal/pkg/resource"
+)
+
+var (
+	ErrLocaleUnknown        = errors.New("unknown locale")
+	ErrLocaleNumberInvalid  = errors.New("invalid number for locale")
+	ErrLocaleNumberGrouping = fmt.Errorf("%w: misplaced group separator", ErrLocaleNumberInvalid)
+)
+
+var (
+	localeFormats     atomic.Value // *NumberFormats
+	localeFormatsOnce sync.Once
+)
+
+// localeZeroDigits maps CLDR numbering systems to their digit zero. The
+// digits of each system are consecutive code points.
+var localeZeroDigits = map[string]rune{
+	"latn":    '0',
+	"arab":    0x0660,
+	"arabext": 0x06F0,
+	"deva":    0x0966,
+	"beng":    0x09E6,
+	"thai":    0x0E50,
+}
+
+// Space-like and apostrophe-like group separators are interchangeable,
+// since users rarely type the exact CLDR character.
+var (
+	localeSpaces      = []rune{' ', 0x00A0, 0x202F}
+	localeApostrophes = []rune{'\'', 0x2019}
+	localeBidiMarks   = []rune{0x061C, 0x200E, 0x200F}
+)
+
+func init() {
+	RegisterRule("locale_number", func(param string) (ValidationRule, error) {
+		locale, flags, _ := strings.Cut(param, "|")
+		var opts LocaleNumberOptions
+		for _, flag := range strings.Split(flags, "|") {
+			switch flag {
+			case "":
+			case "percent":
+				opts.AllowPercent = true
+			case "currency":
+				opts.AllowCurrency = true
+			default:
+				return nil, fmt.Errorf("%w: unknown flag %q", ErrLocaleNumberInvalid, flag)
+			}
+		}
+		if _, ok := LocaleNumberFormats().Lookup(locale); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrLocaleUnknown, locale)
+		}
+		return func(value interface{}) error {
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+			}
+			_, err := LocaleParseNumber(s, locale, opts)
+			return err
+		}, nil
+	})
+}
+
+// NumberFormat holds the number symbols of a locale.
+type NumberFormat struct {
+	Locale  string
+	Decimal rune
+	Group   rune
+	// PrimaryGroup is the size of the group next to the decimal
+	// separator and SecondaryGroup the size of all other groups.
+	PrimaryGroup   int
+	SecondaryGroup int
+	// Zero is the digit zero of the locale's numbering system.
+	Zero       rune
+	Percent    string
+	Minus      string
+	Currencies []string
+}
+
+// NumberFormats is an immutable set of number formats by locale.
+type NumberFormats struct {
+	formats map[string]*NumberFormat
+}
+
+// LocaleNumberOptions configures LocaleParseNumber.
+type LocaleNumberOptions struct {
+	AllowPercent  bool
+	AllowCurrency bool
+	// LenientGrouping accepts group separators anywhere between digits of
+	// the integer part instead of only at the locale's group sizes.
+	LenientGrouping bool
+}
+
+// LocaleNumber is a parsed number. Value is normalized to ASCII digits
+// with "." as decimal separator and no grouping, e.g. "-1234.56", so it
+// can be passed to strconv.ParseFloat or DecimalParse. A percentage keeps
+// its written value: "50 %" has Value "50".
+type LocaleNumber struct {
+	Value    string
+	Percent  bool
+	Currency string
+}
+
+// NewNumberFormats reads number formats with one locale per line:
+// locale, decimal, group, grouping ("3" or "3/2"), numbering system,
+// percent, minus and comma-separated currency symbols. Symbols may use Go
+// escapes such as \u00a0.
+func NewNumberFormats(r io.Reader) (*NumberFormats, error) {
+	t := &NumberFormats{formats: make(map[string]*NumberFormat)}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("line %d: want 8 fields, got %d", n, len(fields))
+		}
+		for i, field := range fields {
+			s, err := strconv.Unquote(`"` + field + `"`)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid symbol %q", n, field)
+			}
+			fields[i] = s
+		}
+
+		f := &NumberFormat{
+			Locale:     fields[0],
+			Percent:    fields[5],
+			Minus:      fields[6],
+			Currencies: strings.Split(fields[7], ","),
+		}
+		var ok bool
+		if f.Decimal, ok = localeSingleRune(fields[1]); !ok {
+			return nil, fmt.Errorf("line %d: invalid decimal separator %q", n, fields[1])
+		}
+		if f.Group, ok = localeSingleRune(fields[2]); !ok || f.Group == f.Decimal {
+			return nil, fmt.Errorf("line %d: invalid group separator %q", n, fields[2])
+		}
+		primary, secondary, hasSecondary := strings.Cut(fields[3], "/")
+		if !hasSecondary {
+			secondary = primary
+		}
+		p, err1 := strconv.Atoi(primary)
+		s, err2 := strconv.Atoi(secondary)
+		if err1 != nil || err2 != nil || p < 1 || s < 1 {
+			return nil, fmt.Errorf("line %d: invalid grouping %q", n, fields[3])
+		}
+		f.PrimaryGroup, f.SecondaryGroup = p, s
+		if f.Zero, ok = localeZeroDigits[fields[4]]; !ok {
+			return nil, fmt.Errorf("line %d: unknown numbering system %q", n, fields[4])
+		}
+		t.formats[localeCanonical(f.Locale)] = f
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *NumberFormats) Len() int {
+	return len(t.formats)
+}
+
+// Locales returns the sorted locale tags of t.
+func (t *NumberFormats) Locales() []string {
+	locales := make([]string, 0, len(t.formats))
+	for _, f := range t.formats {
+		locales = append(locales, f.Locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Lookup returns the format of a BCP 47 locale such as "de-CH" or "de_CH",
+// falling back to its parent locales: "de-LU" uses "de".
+func (t *NumberFormats) Lookup(locale string) (*NumberFormat, bool) {
+	tag := localeCanonical(locale)
+	for tag != "" {
+		if f, ok := t.formats[tag]; ok {
+			return f, true
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return nil, false
+}
+
+// Subset returns the formats of the given locales only, so that
+// applications can restrict input to the locales they support.
+func (t *NumberFormats) Subset(locales ...string) (*NumberFormats, error) {
+	sub := &NumberFormats{formats: make(map[string]*NumberFormat)}
+	for _, locale := range locales {
+		f, ok := t.Lookup(locale)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrLocaleUnknown, locale)
+		}
+		// A locale served by a parent's format keeps its own tag.
+		tag := localeCanonical(locale)
+		if localeCanonical(f.Locale) != tag {
+			child := *f
+			child.Locale = strings.ReplaceAll(locale, "_", "-")
+			f = &child
+		}
+		sub.formats[tag] = f
+	}
+	return sub, nil
+}
+
+// LocaleNumberFormats returns the formats used by the Locale functions.
+// The embedded CLDR data is parsed on first use.
+func LocaleNumberFormats() *NumberFormats {
+	localeFormatsOnce.Do(func() {
+		localeFormats.CompareAndSwap(nil, mustNumberFormats(resource.NumberFormats))
+	})
+	return localeFormats.Load().(*NumberFormats)
+}
+
+// LocaleSetNumberFormats atomically replaces the formats used by the
+// Locale functions.
+func LocaleSetNumberFormats(t *NumberFormats) {
+	localeFormats.Store(t)
+}
+
+func LocaleLoadNumberFormats(r io.Reader) error {
+	t, err := NewNumberFormats(r)
+	if err != nil {
+		return err
+	}
+	LocaleSetNumberFormats(t)
+	return nil
+}
+
+func LocaleLoadNumberFormatsFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := LocaleLoadNumberFormats(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// LocaleParseNumber parses a number written for locale, such as
+// "1.234,56" in "de" or "1'234.56" in "de-CH". Digits may be ASCII or
+// those of the locale's numbering system, but not both.
+func LocaleParseNumber(s, locale string, opts LocaleNumberOptions) (*LocaleNumber, error) {
+	f, ok := LocaleNumberFormats().Lookup(locale)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrLocaleUnknown, locale)
+	}
+	return f.Parse(s, opts)
+}
+
+func LocaleNumberIsValid(s, locale string) bool {
+	_, err := LocaleParseNumber(s, locale, LocaleNumberOptions{})
+	return err == nil
+}
+
+// Parse parses a number in the format f as described for
+// LocaleParseNumber.
+func (f *NumberFormat) Parse(s string, opts LocaleNumberOptions) (*LocaleNumber, error) {
+	fail := func(reason string) (*LocaleNumber, error) {
+		return nil, fmt.Errorf("%w: %q in %s: %s", ErrLocaleNumberInvalid, s, f.Locale, reason)
+	}
+
+	rest := strings.Map(func(r rune) rune {
+		if localeContainsRune(localeBidiMarks, r) {
+			return -1
+		}
+		return r
+	}, s)
+	n := &LocaleNumber{}
+
+	// Affixes: a sign, a currency and a percent sign may precede or
+	// follow the number, separated from it by optional spaces.
+	negative, hasSign := false, false
+	for changed := true; changed; {
+		changed = false
+		if !hasSign {
+			if r, ok := f.cutSign(&rest); ok {
+				negative, hasSign, changed = r, true, true
+			}
+		}
+		if sym, ok := f.cutSymbol(&rest, opts, n, true); ok {
+			changed = true
+			if sym == "" {
+				return fail("duplicate symbol")
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		if sym, ok := f.cutSymbol(&rest, opts, n, false); ok {
+			changed = true
+			if sym == "" {
+				return fail("duplicate symbol")
+			}
+		}
+	}
+
+	intPart, fracPart := rest, ""
+	if i := strings.IndexRune(rest, f.Decimal); i >= 0 {
+		intPart, fracPart = rest[:i], rest[i+utf8.RuneLen(f.Decimal):]
+		if fracPart == "" {
+			return fail("missing fraction digits")
+		}
+	}
+
+	var zero rune = -1
+	digits := func(part string) (string, bool) {
+		var b strings.Builder
+		for _, r := range part {
+			z := f.Zero
+			if r >= '0' && r <= '9' {
+				z = '0'
+			}
+			if r < z || r > z+9 || zero >= 0 && z != zero {
+				return "", false
+			}
+			zero = z
+			b.WriteByte(byte('0' + r - z))
+		}
+		return b.String(), true
+	}
+
+	groups := f.splitGroups(intPart)
+	if len(groups) > 1 && !opts.LenientGrouping {
+		for i, g := range groups {
+			size := utf8.RuneCountInString(g)
+			switch {
+			case i == len(groups)-1 && size != f.PrimaryGroup,
+				i > 0 && i < len(groups)-1 && size != f.SecondaryGroup,
+				i == 0 && (size < 1 || size > f.SecondaryGroup):
+				return nil, fmt.Errorf("%w: %q in %s", ErrLocaleNumberGrouping, s, f.Locale)
+			}
+		}
+	}
+	var value strings.Builder
+	if negative {
+		value.WriteByte('-')
+	}
+	for _, g := range groups {
+		if g == "" && len(groups) > 1 {
+			return nil, fmt.Errorf("%w: %q in %s", ErrLocaleNumberGrouping, s, f.Locale)
+		}
+		d, ok := digits(g)
+		if !ok {
+			return fail("invalid digit")
+		}
+		value.WriteString(d)
+	}
+	if intPart == "" {
+		if fracPart == "" {
+			return fail("missing digits")
+		}
+		value.WriteByte('0')
+	}
+	if fracPart != "" {
+		d, ok := digits(fracPart)
+		if !ok {
+			return fail("invalid digit")
+		}
+		value.WriteByte('.')
+		value.WriteString(d)
+	}
+	n.Value = value.String()
+	return n, nil
+}
+
+// cutSign removes a leading minus or plus sign from *s.
+func (f *NumberFormat) cutSign(s *string) (negative, ok bool) {
+	for _, sign := range []string{f.Minus, "-", "+"} {
+		if strings.HasPrefix(*s, sign) {
+			*s = (*s)[len(sign):]
+			return sign != "+", true
+		}
+	}
+	return false, false
+}
+
+// cutSymbol removes a percent or currency symbol with its separating
+// spaces from the start or end of *s and records it in n. It returns an
+// empty symbol if n already has a symbol of that kind.
+func (f *NumberFormat) cutSymbol(s *string, opts LocaleNumberOptions, n *LocaleNumber, prefix bool) (string, bool) {
+	var candidates []string
+	if opts.AllowPercent {
+		candidates = append(candidates, f.Percent, "%")
+	}
+	if opts.AllowCurrency {
+		candidates = append(candidates, f.Currencies...)
+		// Any ISO 4217 code may be written instead of a symbol.
+		if len(*s) >= 3 {
+			code := (*s)[len(*s)-3:]
+			if prefix {
+				code = (*s)[:3]
+			}
+			if CurrencyIsValid(code) {
+				candidates = append(candidates, code)
+			}
+		}
+	}
+
+	// Try longer symbols first, so that "US$" is not cut as "$".
+	sort.SliceStable(candidates, func(i, j int) bool { return len(candidates[i]) > len(candidates[j]) })
+	for _, sym := range candidates {
+		var rest string
+		var ok bool
+		if prefix {
+			rest, ok = localeCutPrefix(*s, sym)
+			rest = strings.TrimLeftFunc(rest, func(r rune) bool { return localeContainsRune(localeSpaces, r) })
+		} else {
+			rest, ok = localeCutSuffix(*s, sym)
+			rest = strings.TrimRightFunc(rest, func(r rune) bool { return localeContainsRune(localeSpaces, r) })
+		}
+		if !ok || rest == "" {
+			continue
+		}
+		*s = rest
+		isPercent := sym == f.Percent || sym == "%"
+		if isPercent && n.Percent || !isPercent && n.Currency != "" {
+			return "", true
+		}
+		if isPercent {
+			n.Percent = true
+		} else {
+			n.Currency = sym
+		}
+		return sym, true
+	}
+	return "", false
+}
+
+// splitGroups splits the integer part at group separators, treating all
+// space-like or apostrophe-like separators as equivalent.
+func (f *NumberFormat) splitGroups(s string) []string {
+	equivalent := []rune{f.Group}
+	switch {
+	case localeContainsRune(localeSpaces, f.Group):
+		equivalent = localeSpaces
+	case localeContainsRune(localeApostrophes, f.Group):
+		equivalent = localeApostrophes
+	}
+	var groups []string
+	start := 0
+	for i, r := range s {
+		if localeContainsRune(equivalent, r) {
+			groups = append(groups, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return append(groups, s[start:])
+}
+
+func localeCutPrefix(s, prefix string) (string, bool) {
+	if prefix != "" && strings.HasPrefix(s, prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+func localeCutSuffix(s, suffix string) (string, bool) {
+	if suffix != "" && strings.HasSuffix(s, suffix) {
+		return s[:len(s)-len(suffix)], true
+	}
+	return s, false
+}
+
+func localeContainsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+func localeSingleRune(s string) (rune, bool) {
+	r, size := utf8.DecodeRuneInString(s)
+	return r, r != utf8.RuneError && size == len(s)
+}
+
+// localeCanonical normalizes locale tags for lookups: "de_ch" and "de-CH"
+// are the same locale.
+func localeCanonical(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+func mustNumberFormats(data string) *NumberFormats {
+	t, err := NewNumberFormats(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
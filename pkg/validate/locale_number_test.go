@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestLocaleParseNumber(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s      string
+		locale string
+		opts   validate.LocaleNumberOptions
+	}
+
+	type want struct {
+		value    string
+		percent  bool
+		currency string
+		err      error
+	}
+
+	money := validate.LocaleNumberOptions{AllowCurrency: true}
+	percent := validate.LocaleNumberOptions{AllowPercent: true}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "en", in: in{s: "1,234.56", locale: "en"}, want: want{value: "1234.56"}},
+		{name: "en without grouping", in: in{s: "1234.56", locale: "en-US"}, want: want{value: "1234.56"}},
+		{name: "de", in: in{s: "1.234,56", locale: "de"}, want: want{value: "1234.56"}},
+		{name: "de negative", in: in{s: "-1.234.567,8", locale: "de-DE"}, want: want{value: "-1234567.8"}},
+		{name: "de point as decimal", in: in{s: "1.5", locale: "de"}, want: want{err: validate.ErrLocaleNumberGrouping}},
+		{name: "de lenient grouping", in: in{s: "1.5", locale: "de", opts: validate.LocaleNumberOptions{LenientGrouping: true}}, want: want{value: "15"}},
+		{name: "de-CH apostrophe", in: in{s: "1'234.56", locale: "de-CH"}, want: want{value: "1234.56"}},
+		{name: "de-CH right quote", in: in{s: "1\u2019234.56", locale: "de_CH"}, want: want{value: "1234.56"}},
+		{name: "fr narrow space", in: in{s: "1\u202f234,56", locale: "fr"}, want: want{value: "1234.56"}},
+		{name: "fr plain space", in: in{s: "1 234 567,5", locale: "fr-FR"}, want: want{value: "1234567.5"}},
+		{name: "sv minus sign", in: in{s: "\u221212,5", locale: "sv"}, want: want{value: "-12.5"}},
+		{name: "en-IN lakh", in: in{s: "12,34,567.50", locale: "en-IN"}, want: want{value: "1234567.50"}},
+		{name: "en-IN western grouping", in: in{s: "1,234,567.50", locale: "en-IN"}, want: want{err: validate.ErrLocaleNumberGrouping}},
+		{name: "misplaced group", in: in{s: "12,34.5", locale: "en"}, want: want{err: validate.ErrLocaleNumberGrouping}},
+		{name: "empty group", in: in{s: "1,,234", locale: "en"}, want: want{err: validate.ErrLocaleNumberGrouping}},
+		{name: "leading group", in: in{s: ",234", locale: "en"}, want: want{err: validate.ErrLocaleNumberGrouping}},
+		{name: "fraction only", in: in{s: ",5", locale: "de"}, want: want{value: "0.5"}},
+		{name: "trailing decimal", in: in{s: "5,", locale: "de"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "two decimals", in: in{s: "1.2.3", locale: "en"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "arabic-indic digits", in: in{s: "\u0661\u066c\u0662\u0663\u0664\u066b\u0665", locale: "ar"}, want: want{value: "1234.5"}},
+		{name: "arabic with bidi marks", in: in{s: "\u061c-\u0665\u0660", locale: "ar-EG"}, want: want{value: "-50"}},
+		{name: "extended arabic-indic digits", in: in{s: "\u06f1\u06f2\u066b\u06f5", locale: "fa"}, want: want{value: "12.5"}},
+		{name: "devanagari digits", in: in{s: "\u0967,\u0968\u0969,\u096a\u096b\u096c", locale: "mr"}, want: want{value: "123456"}},
+		{name: "ascii digits in mr", in: in{s: "1,23,456", locale: "mr"}, want: want{value: "123456"}},
+		{name: "mixed digits", in: in{s: "1\u0968", locale: "mr"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "foreign native digits", in: in{s: "\u0661", locale: "en"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "percent", in: in{s: "12,5\u00a0%", locale: "de", opts: percent}, want: want{value: "12.5", percent: true}},
+		{name: "percent prefix", in: in{s: "%10", locale: "tr", opts: percent}, want: want{value: "10", percent: true}},
+		{name: "arabic percent", in: in{s: "\u0665\u0660\u066a", locale: "ar", opts: percent}, want: want{value: "50", percent: true}},
+		{name: "percent not allowed", in: in{s: "12%", locale: "en"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "currency suffix", in: in{s: "1.234,56 \u20ac", locale: "de", opts: money}, want: want{value: "1234.56", currency: "\u20ac"}},
+		{name: "currency prefix", in: in{s: "-$1,234.56", locale: "en", opts: money}, want: want{value: "-1234.56", currency: "$"}},
+		{name: "currency before sign", in: in{s: "$-5", locale: "en", opts: money}, want: want{value: "-5", currency: "$"}},
+		{name: "ISO code", in: in{s: "CHF 1'234.50", locale: "de-CH", opts: money}, want: want{value: "1234.50", currency: "CHF"}},
+		{name: "other ISO code", in: in{s: "1234.50 USD", locale: "de-CH", opts: money}, want: want{value: "1234.50", currency: "USD"}},
+		{name: "longest symbol suffix", in: in{s: "5 US$", locale: "en", opts: money}, want: want{value: "5", currency: "US$"}},
+		{name: "longest symbol prefix", in: in{s: "US$5", locale: "en", opts: money}, want: want{value: "5", currency: "US$"}},
+		{name: "duplicate currency", in: in{s: "$1 $", locale: "en", opts: money}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "currency only", in: in{s: "$", locale: "en", opts: money}, want: want{err: validate.ErrLocaleNumberInvalid}},
+		{name: "fallback locale", in: in{s: "1.234", locale: "de-LU"}, want: want{value: "1234"}},
+		{name: "unknown locale", in: in{s: "1", locale: "xx"}, want: want{err: validate.ErrLocaleUnknown}},
+		{name: "empty", in: in{s: "", locale: "en"}, want: want{err: validate.ErrLocaleNumberInvalid}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.LocaleParseNumber(tt.in.s, tt.in.locale, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("LocaleParseNumber(%q, %q) error = %v, want %v", tt.in.s, tt.in.locale, err, tt.want.err)
+			}
+			if err != nil {
+				return
+			}
+			if got.Value != tt.want.value || got.Percent != tt.want.percent || got.Currency != tt.want.currency {
+				t.Errorf("LocaleParseNumber(%q, %q) = %+v, want %+v", tt.in.s, tt.in.locale, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberFormats(t *testing.T) {
+	t.Parallel()
+
+	formats := validate.LocaleNumberFormats()
+	if formats.Len() < 30 {
+		t.Errorf("LocaleNumberFormats().Len() = %d, want at least 30", formats.Len())
+	}
+
+	sub, err := formats.Subset("de-CH", "en")
+	if err != nil {
+		t.Fatalf("Subset() error = %v", err)
+	}
+	if _, ok := sub.Lookup("fr"); ok {
+		t.Errorf("Subset().Lookup(%q) = true, want false", "fr")
+	}
+	f, ok := sub.Lookup("de-CH")
+	if !ok {
+		t.Fatalf("Subset().Lookup(%q) = false, want true", "de-CH")
+	}
+	if n, err := f.Parse("1'000", validate.LocaleNumberOptions{}); err != nil || n.Value != "1000" {
+		t.Errorf("Parse(%q) = %v, %v, want 1000", "1'000", n, err)
+	}
+	sub, err = formats.Subset("de_LU")
+	if err != nil {
+		t.Fatalf("Subset() error = %v", err)
+	}
+	if got := sub.Locales(); len(got) != 1 || got[0] != "de-LU" {
+		t.Errorf("Subset(%q).Locales() = %q, want [de-LU]", "de_LU", got)
+	}
+	if f, ok := sub.Lookup("de-LU"); !ok || f.Locale != "de-LU" || f.Decimal != ',' {
+		t.Errorf("Subset(%q).Lookup(%q) = %+v, %v", "de_LU", "de-LU", f, ok)
+	}
+	if _, ok := sub.Lookup("de"); ok {
+		t.Errorf("Subset(%q).Lookup(%q) = true, want false", "de_LU", "de")
+	}
+	if _, err := formats.Subset("xx"); !errors.Is(err, validate.ErrLocaleUnknown) {
+		t.Errorf("Subset(%q) error = %v, want %v", "xx", err, validate.ErrLocaleUnknown)
+	}
+
+	for _, data := range []string{
+		"en . , 3 latn % -",
+		"en . . 3 latn % - $",
+		"en . , 0 latn % - $",
+		"en . , 3 roman % - $",
+	} {
+		if _, err := validate.NewNumberFormats(strings.NewReader(data)); err == nil {
+			t.Errorf("NewNumberFormats(%q) error = nil", data)
+		}
+	}
+}
+
+func TestLocaleSetNumberFormats(t *testing.T) {
+	defer validate.LocaleSetNumberFormats(validate.LocaleNumberFormats())
+
+	if err := validate.LocaleLoadNumberFormats(strings.NewReader("xx , _ 4 latn % - X\n")); err != nil {
+		t.Fatalf("LocaleLoadNumberFormats() error = %v", err)
+	}
+	if !validate.LocaleNumberIsValid("1_2345,5", "xx") {
+		t.Errorf("LocaleNumberIsValid(%q, %q) = false, want true", "1_2345,5", "xx")
+	}
+	if validate.LocaleNumberIsValid("1,5", "en") {
+		t.Errorf("LocaleNumberIsValid(%q, %q) = true, want false", "1,5", "en")
+	}
+}
+
+func TestLocaleNumberRule(t *testing.T) {
+	t.Parallel()
+
+	type form struct {
+		Price string `validate:"locale_number=de-CH|currency"`
+		Rate  string `validate:"locale_number=fr|percent"`
+	}
+
+	if err := validate.ValidateStruct(form{Price: "CHF 1'250.00", Rate: "12,5 %"}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	if err := validate.ValidateStruct(form{Price: "1.250,00", Rate: "12.5"}); !errors.Is(err, validate.ErrLocaleNumberInvalid) {
+		t.Errorf("ValidateStruct() error = %v, want %v", err, validate.ErrLocaleNumberInvalid)
+	}
+	for _, tag := range []string{"locale_number=xx", "locale_number=en|bogus"} {
+		if err := validate.NewValidate().AddNamedRule(tag); err == nil {
+			t.Errorf("AddNamedRule(%q) error = nil", tag)
+		}
+	}
+}
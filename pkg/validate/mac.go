@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+var ErrMACInvalid = errors.New("invalid MAC address")
+
+func init() {
+	RegisterRule("mac", stringRuleFactory(MACIsValid, ErrMACInvalid))
+	RegisterRule("eui48", stringRuleFactory(EUI48IsValid, ErrMACInvalid))
+	RegisterRule("eui64", stringRuleFactory(EUI64IsValid, ErrMACInvalid))
+}
+
+// MACParse parses an EUI-48 or EUI-64 address in colon ("00:00:5e:00:53:01"),
+// hyphen ("00-00-5E-00-53-01") or dot ("0000.5e00.5301") notation.
+func MACParse(s string) (net.HardwareAddr, error) {
+	// Bare hex digits ("00005e005301") are ambiguous with other
+	// identifiers, so a separator is required even where net.ParseMAC
+	// documents and accepts that form.
+	if !strings.ContainsAny(s, ":-.") {
+		return nil, fmt.Errorf("%w: %q", ErrMACInvalid, s)
+	}
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrMACInvalid, s)
+	}
+	// net.ParseMAC also accepts 20-octet IP over InfiniBand addresses.
+	if len(mac) != 6 && len(mac) != 8 {
+		return nil, fmt.Errorf("%w: %d octets", ErrMACInvalid, len(mac))
+	}
+	return mac, nil
+}
+
+func MACIsValid(s string) bool {
+	_, err := MACParse(s)
+	return err == nil
+}
+
+func EUI48IsValid(s string) bool {
+	mac, err := MACParse(s)
+	return err == nil && len(mac) == 6
+}
+
+func EUI64IsValid(s string) bool {
+	mac, err := MACParse(s)
+	return err == nil && len(mac) == 8
+}
@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestMACIsValid(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		mac   bool
+		eui48 bool
+		eui64 bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "eui-48 colon",
+			in:   in{s: "00:00:5e:00:53:01"},
+			want: want{mac: true, eui48: true, eui64: false},
+		},
+		{
+			name: "eui-48 hyphen",
+			in:   in{s: "00-00-5E-00-53-01"},
+			want: want{mac: true, eui48: true, eui64: false},
+		},
+		{
+			name: "eui-48 dot",
+			in:   in{s: "0000.5e00.5301"},
+			want: want{mac: true, eui48: true, eui64: false},
+		},
+		{
+			name: "eui-64 colon",
+			in:   in{s: "02:00:5e:10:00:00:00:01"},
+			want: want{mac: true, eui48: false, eui64: true},
+		},
+		{
+			name: "eui-64 dot",
+			in:   in{s: "0200.5e10.0000.0001"},
+			want: want{mac: true, eui48: false, eui64: true},
+		},
+		{
+			name: "infiniband",
+			in:   in{s: "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01"},
+			want: want{mac: false, eui48: false, eui64: false},
+		},
+		{
+			name: "mixed separators",
+			in:   in{s: "00:00-5e:00:53:01"},
+			want: want{mac: false, eui48: false, eui64: false},
+		},
+		{
+			name: "no separators",
+			in:   in{s: "00005e005301"},
+			want: want{mac: false, eui48: false, eui64: false},
+		},
+		{
+			name: "invalid hex",
+			in:   in{s: "00:00:5g:00:53:01"},
+			want: want{mac: false, eui48: false, eui64: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.MACIsValid(tt.in.s); got != tt.want.mac {
+				t.Errorf("MACIsValid(%q) = %v, want %v", tt.in.s, got, tt.want.mac)
+			}
+			if got := validate.EUI48IsValid(tt.in.s); got != tt.want.eui48 {
+				t.Errorf("EUI48IsValid(%q) = %v, want %v", tt.in.s, got, tt.want.eui48)
+			}
+			if got := validate.EUI64IsValid(tt.in.s); got != tt.want.eui64 {
+				t.Errorf("EUI64IsValid(%q) = %v, want %v", tt.in.s, got, tt.want.eui64)
+			}
+		})
+	}
+}
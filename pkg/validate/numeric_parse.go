@@ -0,0 +1,327 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrNumericSyntax = errors.New("invalid number syntax")
+
+// NumericParseOptions configures NumericParseInt, NumericParseUint and
+// NumericParseFloat.
+type NumericParseOptions struct {
+	// BitSize is the size of the target type: 8, 16, 32 or 64 for
+	// integers and 32 or 64 for floats. Zero means 64.
+	BitSize int
+	// Base is the integer base from 2 to 36. Zero means decimal unless
+	// the input has a 0x, 0o or 0b prefix. A prefix matching an explicit
+	// base is also accepted.
+	Base int
+	// Separator, if not zero, may appear between two digits, as in
+	// "1_000_000" or "1'234.5".
+	Separator rune
+	// AllowNaN and AllowInf accept "NaN", "Inf" and "Infinity" in floats.
+	AllowNaN bool
+	AllowInf bool
+	// ForbidExponent rejects floats in exponent notation such as "1e6".
+	ForbidExponent bool
+}
+
+// NumericError reports the input that failed to parse. Err wraps
+// ErrNumericSyntax or ErrNumericRange.
+type NumericError struct {
+	Input string
+	Err   error
+}
+
+func (e *NumericError) Error() string {
+	return fmt.Sprintf("parsing %q: %v", e.Input, e.Err)
+}
+
+func (e *NumericError) Unwrap() error {
+	return e.Err
+}
+
+func init() {
+	RegisterRule("int", numericParseRuleFactory(func(s string, opts NumericParseOptions) error {
+		_, err := NumericParseInt(s, opts)
+		return err
+	}))
+	RegisterRule("uint", numericParseRuleFactory(func(s string, opts NumericParseOptions) error {
+		_, err := NumericParseUint(s, opts)
+		return err
+	}))
+	RegisterRule("float", numericParseRuleFactory(func(s string, opts NumericParseOptions) error {
+		_, err := NumericParseFloat(s, opts)
+		return err
+	}))
+}
+
+// NumericParseInt parses a signed integer that must fit in opts.BitSize
+// bits.
+func NumericParseInt(s string, opts NumericParseOptions) (int64, error) {
+	sign, digits, base, err := numericPrepareInt(s, opts)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(sign+digits, base, numericBitSize(opts.BitSize))
+	if err != nil {
+		return 0, numericWrapErr(s, err, opts.BitSize, "int")
+	}
+	return n, nil
+}
+
+// NumericParseUint parses an unsigned integer that must fit in
+// opts.BitSize bits. A minus sign is a syntax error, even for "-0".
+func NumericParseUint(s string, opts NumericParseOptions) (uint64, error) {
+	sign, digits, base, err := numericPrepareInt(s, opts)
+	if err != nil {
+		return 0, err
+	}
+	if sign == "-" {
+		return 0, &NumericError{Input: s, Err: fmt.Errorf("%w: sign on unsigned integer", ErrNumericSyntax)}
+	}
+	n, err := strconv.ParseUint(digits, base, numericBitSize(opts.BitSize))
+	if err != nil {
+		return 0, numericWrapErr(s, err, opts.BitSize, "uint")
+	}
+	return n, nil
+}
+
+// NumericParseFloat parses a decimal floating-point number such as
+// "-1.5e3". Values beyond the largest float of opts.BitSize bits, such
+// as "1e400", are range errors rather than infinities.
+func NumericParseFloat(s string, opts NumericParseOptions) (float64, error) {
+	fail := func(format string, args ...interface{}) (float64, error) {
+		return 0, &NumericError{Input: s, Err: fmt.Errorf("%w: "+format, append([]interface{}{ErrNumericSyntax}, args...)...)}
+	}
+	bitSize := numericBitSize(opts.BitSize)
+	if bitSize != 32 && bitSize != 64 {
+		return fail("bit size %d", opts.BitSize)
+	}
+
+	sign, rest := numericCutSign(s)
+	switch strings.ToLower(rest) {
+	case "nan":
+		if !opts.AllowNaN || sign != "" {
+			return fail("NaN not allowed")
+		}
+		return strconv.ParseFloat(rest, bitSize)
+	case "inf", "infinity":
+		if !opts.AllowInf {
+			return fail("infinity not allowed")
+		}
+		return strconv.ParseFloat(s, bitSize)
+	}
+
+	mantissa, exponent := rest, ""
+	if i := strings.IndexAny(rest, "eE"); i >= 0 {
+		if opts.ForbidExponent {
+			return fail("exponent not allowed")
+		}
+		mantissa, exponent = rest[:i], rest[i:]
+	}
+	intPart, fracPart, hasPoint := strings.Cut(mantissa, ".")
+	intDigits, err := numericStripSeparators(intPart, 10, opts.Separator)
+	if err != nil {
+		return fail("%v", err)
+	}
+	fracDigits, err := numericStripSeparators(fracPart, 10, opts.Separator)
+	if err != nil {
+		return fail("%v", err)
+	}
+	if intDigits == "" && fracDigits == "" {
+		return fail("missing digits")
+	}
+	if exponent != "" {
+		expSign, expDigits := numericCutSign(exponent[1:])
+		if expDigits == "" || strings.Trim(expDigits, "0123456789") != "" {
+			return fail("invalid exponent %q", exponent)
+		}
+		exponent = "e" + expSign + expDigits
+	}
+
+	normalized := sign + intDigits
+	if hasPoint {
+		normalized += "." + fracDigits
+	}
+	f, err := strconv.ParseFloat(normalized+exponent, bitSize)
+	if err != nil {
+		return 0, numericWrapErr(s, err, bitSize, "float")
+	}
+	return f, nil
+}
+
+func NumericIntIsValid(s string, opts NumericParseOptions) bool {
+	_, err := NumericParseInt(s, opts)
+	return err == nil
+}
+
+func NumericUintIsValid(s string, opts NumericParseOptions) bool {
+	_, err := NumericParseUint(s, opts)
+	return err == nil
+}
+
+func NumericFloatIsValid(s string, opts NumericParseOptions) bool {
+	_, err := NumericParseFloat(s, opts)
+	return err == nil
+}
+
+// numericPrepareInt splits s into its sign and digits, resolving the base
+// and removing separators.
+func numericPrepareInt(s string, opts NumericParseOptions) (string, string, int, error) {
+	fail := func(reason string) (string, string, int, error) {
+		return "", "", 0, &NumericError{Input: s, Err: fmt.Errorf("%w: %s", ErrNumericSyntax, reason)}
+	}
+	base := opts.Base
+	if base != 0 && (base < 2 || base > 36) {
+		return fail(fmt.Sprintf("base %d", base))
+	}
+	switch numericBitSize(opts.BitSize) {
+	case 8, 16, 32, 64:
+	default:
+		return fail(fmt.Sprintf("bit size %d", opts.BitSize))
+	}
+
+	sign, rest := numericCutSign(s)
+	if len(rest) > 2 && rest[0] == '0' {
+		prefixBase := 0
+		switch rest[1] {
+		case 'x', 'X':
+			prefixBase = 16
+		case 'o', 'O':
+			prefixBase = 8
+		case 'b', 'B':
+			prefixBase = 2
+		}
+		// In bases above 11 "0b" is a number, not a prefix.
+		if prefixBase != 0 && (base == 0 || base == prefixBase) {
+			base = prefixBase
+			rest = rest[2:]
+		}
+	}
+	if base == 0 {
+		base = 10
+	}
+
+	digits, err := numericStripSeparators(rest, base, opts.Separator)
+	if err != nil {
+		return fail(err.Error())
+	}
+	if digits == "" {
+		return fail("missing digits")
+	}
+	return sign, digits, base, nil
+}
+
+// numericStripSeparators checks that s consists of digits in base with
+// sep only between two digits, and returns the digits.
+func numericStripSeparators(s string, base int, sep rune) (string, error) {
+	var b strings.Builder
+	prevDigit := false
+	for i, r := range s {
+		if sep != 0 && r == sep {
+			next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+			if !prevDigit || !numericIsDigit(next, base) {
+				return "", fmt.Errorf("misplaced separator %q", sep)
+			}
+			prevDigit = false
+			continue
+		}
+		if !numericIsDigit(r, base) {
+			return "", fmt.Errorf("invalid digit %q in base %d", r, base)
+		}
+		b.WriteRune(r)
+		prevDigit = true
+	}
+	return b.String(), nil
+}
+
+func numericIsDigit(r rune, base int) bool {
+	var d int
+	switch {
+	case r >= '0' && r <= '9':
+		d = int(r - '0')
+	case r >= 'a' && r <= 'z':
+		d = int(r-'a') + 10
+	case r >= 'A' && r <= 'Z':
+		d = int(r-'A') + 10
+	default:
+		return false
+	}
+	return d < base
+}
+
+func numericCutSign(s string) (string, string) {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '+' {
+			return "", s[1:]
+		}
+		return "-", s[1:]
+	}
+	return "", s
+}
+
+func numericBitSize(bitSize int) int {
+	if bitSize == 0 {
+		return 64
+	}
+	return bitSize
+}
+
+func numericWrapErr(s string, err error, bitSize int, typ string) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &NumericError{Input: s, Err: fmt.Errorf("%w: does not fit in %s%d", ErrNumericRange, typ, numericBitSize(bitSize))}
+	}
+	return &NumericError{Input: s, Err: ErrNumericSyntax}
+}
+
+// numericParseRuleFactory builds the int, uint and float rules, whose
+// optional parameter is the bit size, e.g. `validate:"uint=16"`.
+func numericParseRuleFactory(parse func(string, NumericParseOptions) error) RuleFactory {
+	return func(param string) (ValidationRule, error) {
+		var opts NumericParseOptions
+		if param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bit size %q", ErrNumericParam, param)
+			}
+			opts.BitSize = n
+			if err := parse("0", opts); err != nil {
+				return nil, fmt.Errorf("%w: bit size %q", ErrNumericParam, param)
+			}
+		}
+		return func(value interface{}) error {
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+			}
+			return parse(s, opts)
+		}, nil
+	}
+}
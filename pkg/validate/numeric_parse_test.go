@@ -0,0 +1,218 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestNumericParseInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.NumericParseOptions
+	}
+
+	type want struct {
+		n   int64
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "decimal", in: in{s: "-42"}, want: want{n: -42}},
+		{name: "plus sign", in: in{s: "+42"}, want: want{n: 42}},
+		{name: "leading zero is decimal", in: in{s: "017"}, want: want{n: 17}},
+		{name: "hex prefix", in: in{s: "0xFF"}, want: want{n: 255}},
+		{name: "negative binary prefix", in: in{s: "-0b101"}, want: want{n: -5}},
+		{name: "octal prefix", in: in{s: "0o17"}, want: want{n: 15}},
+		{name: "explicit base with prefix", in: in{s: "0x1f", opts: validate.NumericParseOptions{Base: 16}}, want: want{n: 31}},
+		{name: "explicit base without prefix", in: in{s: "1f", opts: validate.NumericParseOptions{Base: 16}}, want: want{n: 31}},
+		{name: "0b is hex digits", in: in{s: "0b1", opts: validate.NumericParseOptions{Base: 16}}, want: want{n: 0xb1}},
+		{name: "prefix for other base", in: in{s: "0x1f", opts: validate.NumericParseOptions{Base: 8}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "separator", in: in{s: "1_000_000", opts: validate.NumericParseOptions{Separator: '_'}}, want: want{n: 1000000}},
+		{name: "separator not allowed", in: in{s: "1_000"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "leading separator", in: in{s: "_1000", opts: validate.NumericParseOptions{Separator: '_'}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "double separator", in: in{s: "1__000", opts: validate.NumericParseOptions{Separator: '_'}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "separator after prefix", in: in{s: "0x_ff", opts: validate.NumericParseOptions{Separator: '_'}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "int8 max", in: in{s: "127", opts: validate.NumericParseOptions{BitSize: 8}}, want: want{n: 127}},
+		{name: "int8 overflow", in: in{s: "128", opts: validate.NumericParseOptions{BitSize: 8}}, want: want{err: validate.ErrNumericRange}},
+		{name: "int64 overflow", in: in{s: "9223372036854775808"}, want: want{err: validate.ErrNumericRange}},
+		{name: "invalid bit size", in: in{s: "1", opts: validate.NumericParseOptions{BitSize: 12}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "empty", in: in{s: ""}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "sign only", in: in{s: "-"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "space", in: in{s: " 1"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "float", in: in{s: "1.0"}, want: want{err: validate.ErrNumericSyntax}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.NumericParseInt(tt.in.s, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("NumericParseInt(%q) error = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+			if got != tt.want.n {
+				t.Errorf("NumericParseInt(%q) = %d, want %d", tt.in.s, got, tt.want.n)
+			}
+		})
+	}
+}
+
+func TestNumericParseUint(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.NumericParseOptions
+	}
+
+	type want struct {
+		n   uint64
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "uint16 max", in: in{s: "65535", opts: validate.NumericParseOptions{BitSize: 16}}, want: want{n: 65535}},
+		{name: "uint16 overflow", in: in{s: "65536", opts: validate.NumericParseOptions{BitSize: 16}}, want: want{err: validate.ErrNumericRange}},
+		{name: "uint64 max", in: in{s: "0xffff_ffff_ffff_ffff", opts: validate.NumericParseOptions{Separator: '_'}}, want: want{n: math.MaxUint64}},
+		{name: "negative", in: in{s: "-1"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "negative zero", in: in{s: "-0"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "base 36", in: in{s: "zz", opts: validate.NumericParseOptions{Base: 36}}, want: want{n: 1295}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.NumericParseUint(tt.in.s, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("NumericParseUint(%q) error = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+			if got != tt.want.n {
+				t.Errorf("NumericParseUint(%q) = %d, want %d", tt.in.s, got, tt.want.n)
+			}
+		})
+	}
+}
+
+func TestNumericParseFloat(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		opts validate.NumericParseOptions
+	}
+
+	type want struct {
+		f   float64
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "decimal", in: in{s: "-1.5"}, want: want{f: -1.5}},
+		{name: "exponent", in: in{s: "1.5e3"}, want: want{f: 1500}},
+		{name: "negative exponent", in: in{s: "15E-1"}, want: want{f: 1.5}},
+		{name: "no integer part", in: in{s: ".5"}, want: want{f: 0.5}},
+		{name: "no fraction", in: in{s: "5."}, want: want{f: 5}},
+		{name: "separator", in: in{s: "1'234.56", opts: validate.NumericParseOptions{Separator: '\''}}, want: want{f: 1234.56}},
+		{name: "separator before point", in: in{s: "1'.5", opts: validate.NumericParseOptions{Separator: '\''}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "exponent forbidden", in: in{s: "1e6", opts: validate.NumericParseOptions{ForbidExponent: true}}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "empty exponent", in: in{s: "1e"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "overflow", in: in{s: "1e400"}, want: want{err: validate.ErrNumericRange}},
+		{name: "float32 overflow", in: in{s: "1e39", opts: validate.NumericParseOptions{BitSize: 32}}, want: want{err: validate.ErrNumericRange}},
+		{name: "NaN rejected", in: in{s: "NaN"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "Inf rejected", in: in{s: "-Inf"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "Inf allowed", in: in{s: "-Infinity", opts: validate.NumericParseOptions{AllowInf: true}}, want: want{f: math.Inf(-1)}},
+		{name: "hex float", in: in{s: "0x1p-2"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "two points", in: in{s: "1.2.3"}, want: want{err: validate.ErrNumericSyntax}},
+		{name: "point only", in: in{s: "."}, want: want{err: validate.ErrNumericSyntax}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validate.NumericParseFloat(tt.in.s, tt.in.opts)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Fatalf("NumericParseFloat(%q) error = %v, want %v", tt.in.s, err, tt.want.err)
+			}
+			if got != tt.want.f {
+				t.Errorf("NumericParseFloat(%q) = %v, want %v", tt.in.s, got, tt.want.f)
+			}
+		})
+	}
+
+	f, err := validate.NumericParseFloat("nan", validate.NumericParseOptions{AllowNaN: true})
+	if err != nil || !math.IsNaN(f) {
+		t.Errorf("NumericParseFloat(%q) = %v, %v, want NaN", "nan", f, err)
+	}
+
+	var nerr *validate.NumericError
+	if _, err := validate.NumericParseFloat("1e400", validate.NumericParseOptions{}); !errors.As(err, &nerr) || nerr.Input != "1e400" {
+		t.Errorf("NumericParseFloat(%q) error = %v, want *NumericError", "1e400", err)
+	}
+}
+
+func TestNumericParseRules(t *testing.T) {
+	t.Parallel()
+
+	type form struct {
+		Port  string `validate:"uint=16"`
+		Delta string `validate:"int=8"`
+		Ratio string `validate:"float=32"`
+	}
+
+	if err := validate.ValidateStruct(form{Port: "8080", Delta: "-128", Ratio: "0.5"}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	err := validate.ValidateStruct(form{Port: "65536", Delta: "x", Ratio: "NaN"})
+	for _, want := range []error{validate.ErrNumericRange, validate.ErrNumericSyntax} {
+		if !errors.Is(err, want) {
+			t.Errorf("ValidateStruct() error = %v, want %v", err, want)
+		}
+	}
+	if err := validate.NewValidate().AddNamedRule("float=16"); !errors.Is(err, validate.ErrNumericParam) {
+		t.Errorf("AddNamedRule(%q) error = %v, want %v", "float=16", err, validate.ErrNumericParam)
+	}
+}
@@ -0,0 +1,387 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number is satisfied by all integer and floating-point types, including
+// named types such as time.Duration.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Bounds selects which ends of a NumericBetween range are exclusive.
+type Bounds int
+
+const (
+	BoundsInclusive  Bounds = 0
+	BoundsExcludeMin Bounds = 1
+	BoundsExcludeMax Bounds = 2
+	BoundsExclusive         = BoundsExcludeMin | BoundsExcludeMax
+)
+
+var (
+	ErrNumericRange       = errors.New("number out of range")
+	ErrNumericTooSmall    = fmt.Errorf("%w: too small", ErrNumericRange)
+	ErrNumericTooLarge    = fmt.Errorf("%w: too large", ErrNumericRange)
+	ErrNumericNaN         = fmt.Errorf("%w: NaN", ErrNumericRange)
+	ErrNumericNotMultiple = errors.New("number is not a multiple of the step")
+	ErrNumericNotAllowed  = errors.New("number is not one of the allowed values")
+	ErrNumericParam       = errors.New("invalid numeric rule parameter")
+)
+
+func init() {
+	RegisterRule("min", numericBoundRuleFactory(false, false))
+	RegisterRule("max", numericBoundRuleFactory(true, false))
+	RegisterRule("gt", numericBoundRuleFactory(false, true))
+	RegisterRule("lt", numericBoundRuleFactory(true, true))
+	RegisterRule("between", func(param string) (ValidationRule, error) {
+		lo, hi, ok := strings.Cut(param, "|")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, want min|max", ErrNumericParam, param)
+		}
+		minRule, err := numericBoundRuleFactory(false, false)(lo)
+		if err != nil {
+			return nil, err
+		}
+		maxRule, err := numericBoundRuleFactory(true, false)(hi)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}) error {
+			if err := minRule(value); err != nil {
+				return err
+			}
+			return maxRule(value)
+		}, nil
+	})
+	RegisterRule("positive", func(string) (ValidationRule, error) {
+		return numericBoundRuleFactory(false, true)("0")
+	})
+	RegisterRule("nonnegative", func(string) (ValidationRule, error) {
+		return numericBoundRuleFactory(false, false)("0")
+	})
+	RegisterRule("multiple_of", func(param string) (ValidationRule, error) {
+		step, ok := new(big.Rat).SetString(param)
+		if !ok || step.Sign() == 0 {
+			return nil, fmt.Errorf("%w: step %q", ErrNumericParam, param)
+		}
+		return func(value interface{}) error {
+			x, err := numericReflectRat(value)
+			if err != nil {
+				return err
+			}
+			if !new(big.Rat).Quo(x, step).IsInt() {
+				return fmt.Errorf("%w: %v, step %s", ErrNumericNotMultiple, value, param)
+			}
+			return nil
+		}, nil
+	})
+	RegisterRule("oneof", func(param string) (ValidationRule, error) {
+		values := strings.Split(param, "|")
+		for _, s := range values {
+			if _, err := numericParseParam(s, reflect.Float64); err != nil {
+				return nil, err
+			}
+		}
+		return func(value interface{}) error {
+			x, kind, err := numericReflect(value)
+			if err != nil {
+				return err
+			}
+			for _, s := range values {
+				y, _ := numericParseParam(s, kind)
+				if x.Cmp(y) == 0 {
+					return nil
+				}
+			}
+			return fmt.Errorf("%w: %v", ErrNumericNotAllowed, value)
+		}, nil
+	})
+}
+
+// NumericMin checks if v >= limit. NaN never satisfies a numeric
+// constraint.
+func NumericMin[T Number](v, limit T) bool {
+	return v >= limit
+}
+
+func NumericMax[T Number](v, limit T) bool {
+	return v <= limit
+}
+
+func NumericBetween[T Number](v, lo, hi T, bounds Bounds) bool {
+	return NumericBetweenValidate(v, lo, hi, bounds) == nil
+}
+
+func NumericPositive[T Number](v T) bool {
+	return v > 0
+}
+
+func NumericNonNegative[T Number](v T) bool {
+	return v >= 0
+}
+
+// NumericMultipleOf checks if v is an integer multiple of step. Floats are
+// compared by their shortest decimal representation, so 0.3 is a multiple
+// of 0.1 even though it is not in binary.
+func NumericMultipleOf[T Number](v, step T) bool {
+	if step == 0 || v != v || step != step {
+		return false
+	}
+	if !numericIsFloat[T]() {
+		if numericIsSigned[T]() {
+			return int64(v)%int64(step) == 0
+		}
+		return uint64(v)%uint64(step) == 0
+	}
+	if math.IsInf(float64(v), 0) || math.IsInf(float64(step), 0) {
+		return v == 0 && !math.IsInf(float64(step), 0)
+	}
+	bits := numericFloatBits[T]()
+	x, _ := new(big.Rat).SetString(strconv.FormatFloat(float64(v), 'g', -1, bits))
+	y, _ := new(big.Rat).SetString(strconv.FormatFloat(float64(step), 'g', -1, bits))
+	return x.Quo(x, y).IsInt()
+}
+
+func NumericOneOf[T Number](v T, values ...T) bool {
+	for _, x := range values {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+// NumericBetweenValidate checks if v lies between lo and hi, returning
+// ErrNumericNaN, ErrNumericTooSmall or ErrNumericTooLarge.
+func NumericBetweenValidate[T Number](v, lo, hi T, bounds Bounds) error {
+	if err := numericCheckBound(v, lo, false, bounds&BoundsExcludeMin != 0); err != nil {
+		return err
+	}
+	return numericCheckBound(v, hi, true, bounds&BoundsExcludeMax != 0)
+}
+
+func NumericMinRule[T Number](limit T) ValidationRule {
+	return numericRule(func(v T) error {
+		return numericCheckBound(v, limit, false, false)
+	})
+}
+
+func NumericMaxRule[T Number](limit T) ValidationRule {
+	return numericRule(func(v T) error {
+		return numericCheckBound(v, limit, true, false)
+	})
+}
+
+func NumericBetweenRule[T Number](lo, hi T, bounds Bounds) ValidationRule {
+	return numericRule(func(v T) error {
+		return NumericBetweenValidate(v, lo, hi, bounds)
+	})
+}
+
+func NumericPositiveRule[T Number]() ValidationRule {
+	return numericRule(func(v T) error {
+		return numericCheckBound(v, 0, false, true)
+	})
+}
+
+func NumericNonNegativeRule[T Number]() ValidationRule {
+	return numericRule(func(v T) error {
+		return numericCheckBound(v, 0, false, false)
+	})
+}
+
+func NumericMultipleOfRule[T Number](step T) ValidationRule {
+	return numericRule(func(v T) error {
+		if !NumericMultipleOf(v, step) {
+			return fmt.Errorf("%w: %v, step %v", ErrNumericNotMultiple, v, step)
+		}
+		return nil
+	})
+}
+
+func NumericOneOfRule[T Number](values ...T) ValidationRule {
+	return numericRule(func(v T) error {
+		if !NumericOneOf(v, values...) {
+			return fmt.Errorf("%w: %v", ErrNumericNotAllowed, v)
+		}
+		return nil
+	})
+}
+
+func numericRule[T Number](check func(T) error) ValidationRule {
+	return func(value interface{}) error {
+		v, ok := value.(T)
+		if !ok {
+			var want T
+			return fmt.Errorf("%w: %T, want %T", ErrUnsupportedType, value, want)
+		}
+		return check(v)
+	}
+}
+
+func numericCheckBound[T Number](v, limit T, upper, exclusive bool) error {
+	if v != v {
+		return ErrNumericNaN
+	}
+	switch {
+	case upper && exclusive && !(v < limit):
+		return fmt.Errorf("%w: %v, must be less than %v", ErrNumericTooLarge, v, limit)
+	case upper && !exclusive && !(v <= limit):
+		return fmt.Errorf("%w: %v, maximum is %v", ErrNumericTooLarge, v, limit)
+	case !upper && exclusive && !(v > limit):
+		return fmt.Errorf("%w: %v, must be greater than %v", ErrNumericTooSmall, v, limit)
+	case !upper && !exclusive && !(v >= limit):
+		return fmt.Errorf("%w: %v, minimum is %v", ErrNumericTooSmall, v, limit)
+	}
+	return nil
+}
+
+func numericIsFloat[T Number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+// numericFloatBits reports whether the float type T has 32 or 64 bits:
+// float32 cannot represent 2^24+1.
+func numericFloatBits[T Number]() int {
+	f := float64(1 << 24)
+	if T(f)+1 == T(f) {
+		return 32
+	}
+	return 64
+}
+
+func numericIsSigned[T Number]() bool {
+	var v T
+	v--
+	return v < 0
+}
+
+// numericBoundRuleFactory builds the min, max, gt and lt rules. The bound
+// is parsed in the type of the field, so "max=0.1" on a float32 field
+// compares against float32(0.1), and integer fields are compared exactly
+// over their whole range.
+func numericBoundRuleFactory(upper, exclusive bool) RuleFactory {
+	return func(param string) (ValidationRule, error) {
+		if _, err := numericParseParam(param, reflect.Float64); err != nil {
+			return nil, err
+		}
+		return func(value interface{}) error {
+			x, kind, err := numericReflect(value)
+			if err != nil {
+				return err
+			}
+			limit, _ := numericParseParam(param, kind)
+			if upper {
+				x, limit = limit, x
+			}
+			switch c := x.Cmp(limit); {
+			case c > 0 || c == 0 && !exclusive:
+				return nil
+			case upper:
+				return fmt.Errorf("%w: %v, limit %s", ErrNumericTooLarge, value, param)
+			default:
+				return fmt.Errorf("%w: %v, limit %s", ErrNumericTooSmall, value, param)
+			}
+		}, nil
+	}
+}
+
+// numericReflect converts an integer or float of any type to an exact
+// big.Float, reporting its kind for parsing parameters.
+func numericReflect(value interface{}) (*big.Float, reflect.Kind, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return new(big.Float).SetInt64(v.Int()), reflect.Int64, nil
+	case v.CanUint():
+		return new(big.Float).SetUint64(v.Uint()), reflect.Uint64, nil
+	case v.CanFloat():
+		f := v.Float()
+		if math.IsNaN(f) {
+			return nil, 0, ErrNumericNaN
+		}
+		return new(big.Float).SetFloat64(f), v.Kind(), nil
+	}
+	return nil, 0, fmt.Errorf("%w: %T, want integer or float", ErrUnsupportedType, value)
+}
+
+// numericReflectRat converts an integer or finite float to a big.Rat,
+// using the shortest decimal representation of floats.
+func numericReflectRat(value interface{}) (*big.Rat, error) {
+	x, kind, err := numericReflect(value)
+	if err != nil {
+		return nil, err
+	}
+	if x.IsInf() {
+		return nil, fmt.Errorf("%w: %v", ErrNumericNotMultiple, value)
+	}
+	if kind == reflect.Float32 || kind == reflect.Float64 {
+		f, _ := x.Float64()
+		bits := 64
+		if kind == reflect.Float32 {
+			bits = 32
+		}
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bits))
+		return r, nil
+	}
+	r, _ := x.Rat(nil)
+	return r, nil
+}
+
+// numericParseParam parses a rule parameter as a literal of the given
+// kind would be: exactly for integers, rounded to 32 or 64 bits for floats.
+// NaN is rejected.
+func numericParseParam(s string, kind reflect.Kind) (*big.Float, error) {
+	if kind != reflect.Float32 && kind != reflect.Float64 {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return new(big.Float).SetInt64(n), nil
+		}
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return new(big.Float).SetUint64(n), nil
+		}
+		kind = reflect.Float64
+	}
+	bits := 64
+	if kind == reflect.Float32 {
+		bits = 32
+	}
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil && !errors.Is(err, strconv.ErrRange) || math.IsNaN(f) {
+		return nil, fmt.Errorf("%w: %q", ErrNumericParam, s)
+	}
+	return new(big.Float).SetFloat64(f), nil
+}
@@ -0,0 +1,263 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestNumericBetween(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "int64 inclusive min", got: validate.NumericBetween(int64(1), 1, 10, validate.BoundsInclusive), want: true},
+		{name: "int64 exclusive min", got: validate.NumericBetween(int64(1), 1, 10, validate.BoundsExcludeMin), want: false},
+		{name: "int64 exclusive max", got: validate.NumericBetween(int64(10), 1, 10, validate.BoundsExcludeMax), want: false},
+		{name: "int64 exclusive inside", got: validate.NumericBetween(int64(5), 1, 10, validate.BoundsExclusive), want: true},
+		{name: "uint32 max", got: validate.NumericBetween(uint32(math.MaxUint32), 0, math.MaxUint32, validate.BoundsInclusive), want: true},
+		{name: "uint64 beyond int64", got: validate.NumericMin(uint64(math.MaxUint64), 1<<63), want: true},
+		{name: "float32", got: validate.NumericMax(float32(0.1), 0.1), want: true},
+		{name: "duration", got: validate.NumericMax(2*time.Second, time.Second), want: false},
+		{name: "NaN min", got: validate.NumericMin(nan, math.Inf(-1)), want: false},
+		{name: "NaN max", got: validate.NumericMax(nan, inf), want: false},
+		{name: "NaN between", got: validate.NumericBetween(nan, 0, 1, validate.BoundsInclusive), want: false},
+		{name: "Inf max", got: validate.NumericMax(inf, math.MaxFloat64), want: false},
+		{name: "Inf between infinite bounds", got: validate.NumericBetween(inf, math.Inf(-1), inf, validate.BoundsInclusive), want: true},
+		{name: "Inf exclusive", got: validate.NumericBetween(inf, math.Inf(-1), inf, validate.BoundsExclusive), want: false},
+		{name: "positive zero", got: validate.NumericPositive(0), want: false},
+		{name: "positive Inf", got: validate.NumericPositive(inf), want: true},
+		{name: "positive NaN", got: validate.NumericPositive(nan), want: false},
+		{name: "non-negative negative zero", got: validate.NumericNonNegative(math.Copysign(0, -1)), want: true},
+		{name: "non-negative int8", got: validate.NumericNonNegative(int8(-1)), want: false},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestNumericMultipleOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "int", got: validate.NumericMultipleOf(15, 5), want: true},
+		{name: "int remainder", got: validate.NumericMultipleOf(16, 5), want: false},
+		{name: "negative int", got: validate.NumericMultipleOf(int64(-15), 5), want: true},
+		{name: "min int64 by -1", got: validate.NumericMultipleOf(int64(math.MinInt64), -1), want: true},
+		{name: "uint64 beyond int64", got: validate.NumericMultipleOf(uint64(math.MaxUint64), 5), want: true},
+		{name: "zero step", got: validate.NumericMultipleOf(10, 0), want: false},
+		{name: "decimal float", got: validate.NumericMultipleOf(0.3, 0.1), want: true},
+		{name: "decimal float remainder", got: validate.NumericMultipleOf(0.35, 0.1), want: false},
+		{name: "float32", got: validate.NumericMultipleOf(float32(0.3), 0.1), want: true},
+		{name: "NaN", got: validate.NumericMultipleOf(math.NaN(), 1), want: false},
+		{name: "Inf", got: validate.NumericMultipleOf(math.Inf(1), 1), want: false},
+		{name: "zero by Inf step", got: validate.NumericMultipleOf(0, math.Inf(1)), want: false},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestNumericOneOf(t *testing.T) {
+	t.Parallel()
+
+	if !validate.NumericOneOf(uint8(3), 1, 2, 3) {
+		t.Errorf("NumericOneOf(3, 1, 2, 3) = false, want true")
+	}
+	if validate.NumericOneOf(math.NaN(), math.NaN()) {
+		t.Errorf("NumericOneOf(NaN, NaN) = true, want false")
+	}
+}
+
+func TestNumericRules(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		rule  validate.ValidationRule
+		value interface{}
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "min",
+			in:   in{rule: validate.NumericMinRule[int32](10), value: int32(10)},
+			want: want{err: nil},
+		},
+		{
+			name: "min too small",
+			in:   in{rule: validate.NumericMinRule[int32](10), value: int32(9)},
+			want: want{err: validate.ErrNumericTooSmall},
+		},
+		{
+			name: "max too large",
+			in:   in{rule: validate.NumericMaxRule(1.5), value: 2.0},
+			want: want{err: validate.ErrNumericTooLarge},
+		},
+		{
+			name: "between NaN",
+			in:   in{rule: validate.NumericBetweenRule(0.0, 1.0, validate.BoundsInclusive), value: math.NaN()},
+			want: want{err: validate.ErrNumericNaN},
+		},
+		{
+			name: "positive",
+			in:   in{rule: validate.NumericPositiveRule[uint](), value: uint(0)},
+			want: want{err: validate.ErrNumericRange},
+		},
+		{
+			name: "multiple of",
+			in:   in{rule: validate.NumericMultipleOfRule(25), value: 60},
+			want: want{err: validate.ErrNumericNotMultiple},
+		},
+		{
+			name: "one of",
+			in:   in{rule: validate.NumericOneOfRule[int16](1, 2), value: int16(2)},
+			want: want{err: nil},
+		},
+		{
+			name: "wrong type",
+			in:   in{rule: validate.NumericMinRule[int32](10), value: int64(10)},
+			want: want{err: validate.ErrUnsupportedType},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.in.rule(tt.in.value)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("rule(%v) error = %v, want %v", tt.in.value, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestNumericStructTags(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		Quantity uint64        `validate:"min=1,max=18446744073709551615"`
+		Discount float32       `validate:"between=0|0.1"`
+		Price    float64       `validate:"positive,multiple_of=0.05"`
+		Offset   int8          `validate:"gt=-128,lt=127"`
+		Delay    time.Duration `validate:"nonnegative"`
+		Size     int           `validate:"oneof=1|2|4|8"`
+	}
+
+	type want struct {
+		errs []error
+	}
+
+	tests := []struct {
+		name string
+		in   order
+		want want
+	}{
+		{
+			name: "valid",
+			in:   order{Quantity: math.MaxUint64, Discount: 0.1, Price: 19.95, Offset: 0, Size: 4},
+			want: want{},
+		},
+		{
+			name: "out of range",
+			in:   order{Quantity: 0, Discount: 0.11, Price: 19.96, Offset: -128, Delay: -1, Size: 3},
+			want: want{errs: []error{
+				validate.ErrNumericTooSmall, validate.ErrNumericTooLarge, validate.ErrNumericNotMultiple,
+				validate.ErrNumericNotAllowed,
+			}},
+		},
+		{
+			name: "NaN",
+			in:   order{Quantity: 1, Price: math.NaN(), Size: 1},
+			want: want{errs: []error{validate.ErrNumericNaN}},
+		},
+		{
+			name: "Inf",
+			in:   order{Quantity: 1, Price: math.Inf(1), Size: 1},
+			want: want{errs: []error{validate.ErrNumericNotMultiple}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate.ValidateStruct(tt.in)
+			if len(tt.want.errs) == 0 && err != nil {
+				t.Fatalf("ValidateStruct() error = %v", err)
+			}
+			for _, want := range tt.want.errs {
+				if !errors.Is(err, want) {
+					t.Errorf("ValidateStruct() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+
+	var errs validate.FieldErrors
+	err := validate.ValidateStruct(order{Quantity: 0, Discount: 0.11, Price: 19.96, Offset: -128, Delay: -1, Size: 3})
+	if !errors.As(err, &errs) || len(errs) != 6 {
+		t.Errorf("ValidateStruct() = %v, want 6 field errors", err)
+	}
+}
+
+func TestNumericRuleParams(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{"min=abc", "max=NaN", "between=1", "multiple_of=0", "oneof=1|x"} {
+		if err := validate.NewValidate().AddNamedRule(tag); !errors.Is(err, validate.ErrNumericParam) {
+			t.Errorf("AddNamedRule(%q) error = %v, want %v", tag, err, validate.ErrNumericParam)
+		}
+	}
+}
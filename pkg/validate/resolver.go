@@ -20,8 +20,17 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 // SOFTWARE.
 
-package resource
+package validate
 
-const (
-	RegexEmailValidation = "^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"
+import (
+	"context"
+	"net"
 )
+
+// Resolver is the subset of *net.Resolver used by validators that need to
+// consult DNS. Tests can substitute a stub implementation, or a
+// *net.Resolver whose Dial function points at a local DNS server.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
@@ -0,0 +1,635 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Components of a version reported by SemverError.
+const (
+	SemverMajor      = "major"
+	SemverMinor      = "minor"
+	SemverPatch      = "patch"
+	SemverPrerelease = "prerelease"
+	SemverBuild      = "build"
+)
+
+var (
+	ErrSemverInvalid     = errors.New("invalid semantic version")
+	ErrSemverConstraint  = errors.New("invalid version constraint")
+	ErrSemverUnsatisfied = errors.New("version does not satisfy constraint")
+	ErrGoModVersion      = errors.New("invalid Go module version")
+)
+
+// Semver is a semantic version as defined by https://semver.org/.
+type Semver struct {
+	Major, Minor, Patch uint64
+	Prerelease          []string
+	Build               []string
+}
+
+// SemverError reports the component of a version that is malformed.
+type SemverError struct {
+	Input     string
+	Component string
+	Value     string
+	Reason    string
+}
+
+func (e *SemverError) Error() string {
+	if e.Component == "" {
+		return fmt.Sprintf("%v %q: %s", ErrSemverInvalid, e.Input, e.Reason)
+	}
+	return fmt.Sprintf("%v %q: %s %q: %s", ErrSemverInvalid, e.Input, e.Component, e.Value, e.Reason)
+}
+
+func (e *SemverError) Unwrap() error {
+	return ErrSemverInvalid
+}
+
+// SemverConstraint is a set of ranges joined by "||", each a list of
+// comparisons that must all hold.
+type SemverConstraint struct {
+	raw  string
+	sets [][]semverComparator
+}
+
+// GoPseudoVersion is a parsed Go module pseudo-version such as
+// "v0.0.0-20191109021931-daa7c04131f5".
+type GoPseudoVersion struct {
+	Version  *Semver
+	Time     time.Time
+	Revision string
+}
+
+type semverComparator struct {
+	op string // one of "=", "!=", "<", "<=", ">", ">="
+	v  Semver
+	// sentinel marks a bound such as <2.0.0-0 that was created by
+	// desugaring rather than written in the constraint. Its prerelease
+	// does not admit prereleases of the same version.
+	sentinel bool
+}
+
+func init() {
+	RegisterRule("semver", stringRuleFactory(SemverIsValid, ErrSemverInvalid))
+	RegisterRule("semver_lenient", stringRuleFactory(SemverIsValidLenient, ErrSemverInvalid))
+	RegisterRule("semver_satisfies", func(param string) (ValidationRule, error) {
+		c, err := SemverParseConstraint(param)
+		if err != nil {
+			return nil, err
+		}
+		return StringRule(func(s string) bool {
+			v, err := SemverParseLenient(s)
+			return err == nil && c.Check(v)
+		}, ErrSemverUnsatisfied), nil
+	})
+	RegisterRule("gomod_version", stringRuleFactory(GoModVersionIsValid, ErrGoModVersion))
+}
+
+// SemverParse parses a strict SemVer 2.0.0 version such as
+// "1.2.3-rc.1+build.5".
+func SemverParse(s string) (*Semver, error) {
+	return semverParse(s, s, false)
+}
+
+// SemverParseLenient is like SemverParse but accepts a "v" prefix and a
+// missing minor or patch number, as in "v1.2".
+func SemverParseLenient(s string) (*Semver, error) {
+	return semverParse(s, strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V"), true)
+}
+
+func SemverIsValid(s string) bool {
+	_, err := SemverParse(s)
+	return err == nil
+}
+
+func SemverIsValidLenient(s string) bool {
+	_, err := SemverParseLenient(s)
+	return err == nil
+}
+
+// SemverSatisfies checks if version, parsed leniently, satisfies
+// constraint.
+func SemverSatisfies(version, constraint string) bool {
+	v, err := SemverParseLenient(version)
+	if err != nil {
+		return false
+	}
+	c, err := SemverParseConstraint(constraint)
+	return err == nil && c.Check(v)
+}
+
+func (v *Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on the precedence of v and o.
+// Build metadata is ignored.
+func (v *Semver) Compare(o *Semver) int {
+	for _, c := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without prerelease has higher precedence.
+	switch {
+	case len(v.Prerelease) == 0 && len(o.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(o.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(o.Prerelease); i++ {
+		if c := semverCompareIdentifier(v.Prerelease[i], o.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.Prerelease) < len(o.Prerelease):
+		return -1
+	case len(v.Prerelease) > len(o.Prerelease):
+		return 1
+	}
+	return 0
+}
+
+// SemverParseConstraint parses constraints in the syntax of npm, e.g.
+// ">=1.2.0 <2.0.0 || ^3.1". Comparisons within a range are separated by
+// spaces or commas. Supported are the operators =, !=, <, <=, >, >=, the
+// tilde (~1.2: >=1.2.0 <1.3.0) and caret (^0.2.3: >=0.2.3 <0.3.0) ranges,
+// hyphen ranges ("1.2 - 2.3") and the wildcards x, X and *.
+//
+// As in npm, a prerelease version only satisfies a range that names a
+// prerelease of the same major, minor and patch version.
+func SemverParseConstraint(s string) (*SemverConstraint, error) {
+	c := &SemverConstraint{raw: s}
+	for _, r := range strings.Split(s, "||") {
+		set, err := semverParseRange(r)
+		if err != nil {
+			return nil, err
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+func SemverConstraintIsValid(s string) bool {
+	_, err := SemverParseConstraint(s)
+	return err == nil
+}
+
+func (c *SemverConstraint) Check(v *Semver) bool {
+	for _, set := range c.sets {
+		if semverSetMatches(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *SemverConstraint) String() string {
+	return c.raw
+}
+
+// GoModVersionIsValid checks if s is a canonical Go module version: a
+// strict semantic version with a "v" prefix whose only allowed build
+// metadata is "+incompatible" for major versions 2 and above.
+func GoModVersionIsValid(s string) bool {
+	return GoModVersionValidate(s) == nil
+}
+
+func GoModVersionValidate(s string) error {
+	if !strings.HasPrefix(s, "v") {
+		return fmt.Errorf("%w: %q: missing \"v\" prefix", ErrGoModVersion, s)
+	}
+	v, err := SemverParse(s[1:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGoModVersion, err)
+	}
+	switch {
+	case len(v.Build) == 0:
+		return nil
+	case len(v.Build) != 1 || v.Build[0] != "incompatible":
+		return fmt.Errorf("%w: %q: build metadata other than +incompatible", ErrGoModVersion, s)
+	case v.Major < 2:
+		return fmt.Errorf("%w: %q: +incompatible requires major version 2 or above", ErrGoModVersion, s)
+	}
+	return nil
+}
+
+func GoPseudoVersionIsValid(s string) bool {
+	_, err := GoPseudoVersionParse(s)
+	return err == nil
+}
+
+// GoPseudoVersionParse parses a pseudo-version in one of the forms
+// vX.0.0-yyyymmddhhmmss-abcdefabcdef, vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef
+// or vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef. Version is the base version
+// the pseudo-version sorts after.
+func GoPseudoVersionParse(s string) (*GoPseudoVersion, error) {
+	if err := GoModVersionValidate(s); err != nil {
+		return nil, err
+	}
+	v, _ := SemverParse(strings.TrimPrefix(s, "v"))
+	pre := v.Prerelease
+	if len(pre) == 0 {
+		return nil, fmt.Errorf("%w: %q is not a pseudo-version", ErrGoModVersion, s)
+	}
+
+	// The timestamp and revision form the last prerelease identifier.
+	last := pre[len(pre)-1]
+	ts, rev, ok := strings.Cut(last, "-")
+	if !ok || len(ts) != 14 || strings.Trim(ts, "0123456789") != "" {
+		return nil, fmt.Errorf("%w: %q: invalid timestamp in %q", ErrGoModVersion, s, last)
+	}
+	t, err := time.Parse("20060102150405", ts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: invalid timestamp %q", ErrGoModVersion, s, ts)
+	}
+	if len(rev) != 12 || strings.Trim(rev, "0123456789abcdef") != "" {
+		return nil, fmt.Errorf("%w: %q: revision %q is not 12 lowercase hex digits", ErrGoModVersion, s, rev)
+	}
+
+	base := &Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch {
+	case len(pre) == 1:
+		// vX.0.0-yyyymmddhhmmss-abcdef123456 has no base version.
+		if v.Minor != 0 || v.Patch != 0 {
+			return nil, fmt.Errorf("%w: %q: pseudo-version without base must be vX.0.0", ErrGoModVersion, s)
+		}
+	case pre[len(pre)-2] != "0":
+		return nil, fmt.Errorf("%w: %q: missing \".0.\" before the timestamp", ErrGoModVersion, s)
+	case len(pre) == 2:
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdef123456 follows vX.Y.Z.
+		if v.Patch == 0 {
+			return nil, fmt.Errorf("%w: %q: patch version must follow a release", ErrGoModVersion, s)
+		}
+		base.Patch--
+	default:
+		base.Prerelease = pre[:len(pre)-2]
+	}
+	return &GoPseudoVersion{Version: base, Time: t, Revision: rev}, nil
+}
+
+func semverParse(input, s string, lenient bool) (*Semver, error) {
+	fail := func(component, value, reason string) error {
+		return &SemverError{Input: input, Component: component, Value: value, Reason: reason}
+	}
+	if s == "" {
+		return nil, fail("", "", "empty")
+	}
+
+	v := &Semver{}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		build := s[i+1:]
+		for _, id := range strings.Split(build, ".") {
+			if !semverIsIdentifier(id) {
+				return nil, fail(SemverBuild, build, "identifiers must be non-empty [0-9A-Za-z-]")
+			}
+			v.Build = append(v.Build, id)
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		pre := s[i+1:]
+		for _, id := range strings.Split(pre, ".") {
+			if !semverIsIdentifier(id) {
+				return nil, fail(SemverPrerelease, pre, "identifiers must be non-empty [0-9A-Za-z-]")
+			}
+			if semverIsNumeric(id) && len(id) > 1 && id[0] == '0' {
+				return nil, fail(SemverPrerelease, pre, "numeric identifier "+strconv.Quote(id)+" has a leading zero")
+			}
+			v.Prerelease = append(v.Prerelease, id)
+		}
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 || len(parts) < 3 && !lenient {
+		return nil, fail("", "", "want MAJOR.MINOR.PATCH")
+	}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	components := []string{SemverMajor, SemverMinor, SemverPatch}
+	for i, p := range parts {
+		switch {
+		case p == "":
+			return nil, fail(components[i], p, "empty")
+		case !semverIsNumeric(p):
+			return nil, fail(components[i], p, "not a number")
+		case len(p) > 1 && p[0] == '0':
+			return nil, fail(components[i], p, "leading zero")
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fail(components[i], p, "out of range")
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+func semverParseRange(s string) ([]semverComparator, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty range in %q", ErrSemverConstraint, s)
+	}
+
+	// Hyphen ranges: "1.2.3 - 2.3.4".
+	if len(fields) == 3 && fields[1] == "-" {
+		lo, err := semverParsePartial(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := semverParsePartial(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		set := []semverComparator{{op: ">=", v: lo.floor()}}
+		if !hi.any() {
+			set = append(set, hi.upper("<="))
+		}
+		return set, nil
+	}
+
+	var set []semverComparator
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		op := strings.TrimRight(f, "0123456789xX*vV.-+abcdefghijklmnopqrstuvwyzABCDEFGHIJKLMNOPQRSTUVWYZ")
+		if op == f {
+			// An operator separated from its version: ">= 1.2.3".
+			if i+1 == len(fields) {
+				return nil, fmt.Errorf("%w: operator %q without version", ErrSemverConstraint, f)
+			}
+			i++
+			f += fields[i]
+		}
+		p, err := semverParsePartial(f[len(op):])
+		if err != nil {
+			return nil, err
+		}
+		comparators, err := p.desugar(op)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+func semverSetMatches(set []semverComparator, v *Semver) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if len(v.Prerelease) == 0 {
+		return true
+	}
+	for _, c := range set {
+		if len(c.v.Prerelease) > 0 && !c.sentinel &&
+			c.v.Major == v.Major && c.v.Minor == v.Minor && c.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func (c semverComparator) matches(v *Semver) bool {
+	cmp := v.Compare(&c.v)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// semverPartial is a version in a constraint, where trailing components
+// may be missing or wildcards. n is the number of components given.
+type semverPartial struct {
+	v Semver
+	n int
+}
+
+func semverParsePartial(s string) (*semverPartial, error) {
+	in := s
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	if s == "" {
+		return nil, fmt.Errorf("%w: missing version", ErrSemverConstraint)
+	}
+
+	core, rest := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, rest = s[:i], s[i:]
+	}
+	p := &semverPartial{}
+	parts := strings.Split(core, ".")
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		if i >= 3 {
+			return nil, fmt.Errorf("%w: %q", ErrSemverConstraint, in)
+		}
+		p.n++
+	}
+	for _, part := range parts[p.n:] {
+		if part != "x" && part != "X" && part != "*" {
+			return nil, fmt.Errorf("%w: %q: wildcard followed by a number", ErrSemverConstraint, in)
+		}
+	}
+	if rest != "" && p.n < 3 {
+		return nil, fmt.Errorf("%w: %q: prerelease on a partial version", ErrSemverConstraint, in)
+	}
+
+	full := strings.Join(append(parts[:p.n:p.n], "0", "0", "0")[:3], ".") + rest
+	if p.n == 0 {
+		full = "0.0.0"
+	}
+	v, err := semverParse(in, full, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSemverConstraint, err)
+	}
+	p.v = *v
+	return p, nil
+}
+
+func (p *semverPartial) any() bool {
+	return p.n == 0
+}
+
+func (p *semverPartial) floor() Semver {
+	return p.v
+}
+
+// bump returns the smallest version above every version matching the
+// first n components of p, as an exclusive bound.
+func (p *semverPartial) bump(n int) Semver {
+	v := Semver{Major: p.v.Major, Minor: p.v.Minor, Patch: p.v.Patch, Prerelease: []string{"0"}}
+	switch n {
+	case 1:
+		v.Major, v.Minor, v.Patch = v.Major+1, 0, 0
+	case 2:
+		v.Minor, v.Patch = v.Minor+1, 0
+	default:
+		v.Patch++
+	}
+	return v
+}
+
+// upper returns the comparator for p as an upper bound with op "<=" or
+// "<".
+func (p *semverPartial) upper(op string) semverComparator {
+	if p.n == 3 {
+		return semverComparator{op: op, v: p.v}
+	}
+	if op == "<=" {
+		return semverSentinel("<", p.bump(p.n))
+	}
+	return semverSentinel("<", semverWithSentinel(p.v))
+}
+
+func (p *semverPartial) desugar(op string) ([]semverComparator, error) {
+	if p.any() {
+		switch op {
+		case "", "=", ">=", "<=", "~", "^":
+			return []semverComparator{{op: ">=", v: Semver{}}}, nil
+		default:
+			// "<*", ">*" and "!=*" match nothing.
+			return []semverComparator{semverSentinel("<", Semver{Prerelease: []string{"0"}})}, nil
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if p.n == 3 {
+			return []semverComparator{{op: "=", v: p.v}}, nil
+		}
+		return []semverComparator{{op: ">=", v: p.v}, semverSentinel("<", p.bump(p.n))}, nil
+	case "!=":
+		if p.n != 3 {
+			return nil, fmt.Errorf("%w: != requires a full version", ErrSemverConstraint)
+		}
+		return []semverComparator{{op: "!=", v: p.v}}, nil
+	case ">=":
+		return []semverComparator{{op: ">=", v: p.v}}, nil
+	case ">":
+		if p.n == 3 {
+			return []semverComparator{{op: ">", v: p.v}}, nil
+		}
+		return []semverComparator{semverSentinel(">=", p.bump(p.n))}, nil
+	case "<", "<=":
+		return []semverComparator{p.upper(op)}, nil
+	case "~":
+		n := p.n
+		if n == 3 {
+			n = 2
+		}
+		return []semverComparator{{op: ">=", v: p.v}, semverSentinel("<", p.bump(n))}, nil
+	case "^":
+		// The caret allows changes that do not modify the left-most
+		// non-zero component given.
+		n := 1
+		switch {
+		case p.v.Major != 0 || p.n == 1:
+		case p.v.Minor != 0 || p.n == 2:
+			n = 2
+		default:
+			n = 3
+		}
+		return []semverComparator{{op: ">=", v: p.v}, semverSentinel("<", p.bump(n))}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown operator %q", ErrSemverConstraint, op)
+}
+
+func semverSentinel(op string, v Semver) semverComparator {
+	return semverComparator{op: op, v: v, sentinel: true}
+}
+
+func semverWithSentinel(v Semver) Semver {
+	if len(v.Prerelease) == 0 {
+		v.Prerelease = []string{"0"}
+	}
+	return v
+}
+
+func semverCompareIdentifier(a, b string) int {
+	an, bn := semverIsNumeric(a), semverIsNumeric(b)
+	switch {
+	case an && bn:
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func semverIsIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func semverIsNumeric(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
@@ -0,0 +1,398 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestSemverParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s       string
+		lenient bool
+	}
+
+	type want struct {
+		s         string
+		component string
+		err       bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "release",
+			in:   in{s: "1.2.3"},
+			want: want{s: "1.2.3"},
+		},
+		{
+			name: "prerelease and build",
+			in:   in{s: "1.0.0-alpha.1+build.5-x"},
+			want: want{s: "1.0.0-alpha.1+build.5-x"},
+		},
+		{
+			name: "leading zero in minor",
+			in:   in{s: "1.02.3"},
+			want: want{component: validate.SemverMinor, err: true},
+		},
+		{
+			name: "leading zero in numeric prerelease",
+			in:   in{s: "1.2.3-01"},
+			want: want{component: validate.SemverPrerelease, err: true},
+		},
+		{
+			name: "leading zero in build",
+			in:   in{s: "1.2.3+01"},
+			want: want{s: "1.2.3+01"},
+		},
+		{
+			name: "empty prerelease identifier",
+			in:   in{s: "1.2.3-rc..1"},
+			want: want{component: validate.SemverPrerelease, err: true},
+		},
+		{
+			name: "invalid build character",
+			in:   in{s: "1.2.3+b_1"},
+			want: want{component: validate.SemverBuild, err: true},
+		},
+		{
+			name: "non-numeric patch",
+			in:   in{s: "1.2.x"},
+			want: want{component: validate.SemverPatch, err: true},
+		},
+		{
+			name: "missing patch",
+			in:   in{s: "1.2"},
+			want: want{err: true},
+		},
+		{
+			name: "v prefix",
+			in:   in{s: "v1.2.3"},
+			want: want{component: validate.SemverMajor, err: true},
+		},
+		{
+			name: "lenient v prefix",
+			in:   in{s: "v1.2.3", lenient: true},
+			want: want{s: "1.2.3"},
+		},
+		{
+			name: "lenient missing patch",
+			in:   in{s: "v1.2-rc.1", lenient: true},
+			want: want{s: "1.2.0-rc.1"},
+		},
+		{
+			name: "lenient missing minor",
+			in:   in{s: "2", lenient: true},
+			want: want{s: "2.0.0"},
+		},
+		{
+			name: "lenient leading zero",
+			in:   in{s: "v01.2.3", lenient: true},
+			want: want{component: validate.SemverMajor, err: true},
+		},
+		{
+			name: "overflow",
+			in:   in{s: "18446744073709551616.0.0"},
+			want: want{component: validate.SemverMajor, err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parse := validate.SemverParse
+			if tt.in.lenient {
+				parse = validate.SemverParseLenient
+			}
+			v, err := parse(tt.in.s)
+			if tt.want.err {
+				var serr *validate.SemverError
+				if !errors.As(err, &serr) || !errors.Is(err, validate.ErrSemverInvalid) {
+					t.Fatalf("SemverParse(%q) error = %v, want *SemverError", tt.in.s, err)
+				}
+				if serr.Component != tt.want.component {
+					t.Errorf("SemverParse(%q) component = %q, want %q", tt.in.s, serr.Component, tt.want.component)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SemverParse(%q) error = %v", tt.in.s, err)
+			}
+			if got := v.String(); got != tt.want.s {
+				t.Errorf("SemverParse(%q) = %q, want %q", tt.in.s, got, tt.want.s)
+			}
+		})
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	t.Parallel()
+
+	// Ordered by precedence as in the SemVer specification.
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := range versions {
+		for j := range versions {
+			a, _ := validate.SemverParse(versions[i])
+			b, _ := validate.SemverParse(versions[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", versions[i], versions[j], got, want)
+			}
+		}
+	}
+
+	a, _ := validate.SemverParse("1.0.0+a")
+	b, _ := validate.SemverParse("1.0.0+b")
+	if a.Compare(b) != 0 {
+		t.Errorf("Compare(%q, %q) != 0, build metadata must be ignored", a, b)
+	}
+}
+
+func TestSemverSatisfies(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		version    string
+		constraint string
+	}
+
+	type want struct {
+		ok bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "range lower", in: in{"1.2.0", ">=1.2.0 <2.0.0"}, want: want{true}},
+		{name: "range upper", in: in{"2.0.0", ">=1.2.0 <2.0.0"}, want: want{false}},
+		{name: "comma separated", in: in{"1.5.0", ">=1.2.0, <2.0.0"}, want: want{true}},
+		{name: "spaced operator", in: in{"1.5.0", ">= 1.2.0"}, want: want{true}},
+		{name: "or second", in: in{"3.4.0", ">=1.2.0 <2.0.0 || ^3.1"}, want: want{true}},
+		{name: "or none", in: in{"4.0.0", ">=1.2.0 <2.0.0 || ^3.1"}, want: want{false}},
+		{name: "caret major", in: in{"1.9.9", "^1.2.3"}, want: want{true}},
+		{name: "caret zero minor", in: in{"0.3.0", "^0.2.3"}, want: want{false}},
+		{name: "caret zero patch", in: in{"0.0.4", "^0.0.3"}, want: want{false}},
+		{name: "caret partial zero", in: in{"0.1.5", "^0.1"}, want: want{true}},
+		{name: "tilde", in: in{"1.2.9", "~1.2.3"}, want: want{true}},
+		{name: "tilde minor bump", in: in{"1.3.0", "~1.2.3"}, want: want{false}},
+		{name: "tilde major only", in: in{"1.9.0", "~1"}, want: want{true}},
+		{name: "wildcard", in: in{"1.2.7", "1.2.x"}, want: want{true}},
+		{name: "wildcard outside", in: in{"1.3.0", "1.2.*"}, want: want{false}},
+		{name: "star", in: in{"5.0.0", "*"}, want: want{true}},
+		{name: "partial less than", in: in{"1.1.9", "<1.2"}, want: want{true}},
+		{name: "partial less equal", in: in{"1.2.9", "<=1.2"}, want: want{true}},
+		{name: "partial greater", in: in{"1.2.9", ">1.2"}, want: want{false}},
+		{name: "hyphen", in: in{"2.3.9", "1.2 - 2.3"}, want: want{true}},
+		{name: "hyphen upper", in: in{"2.4.0", "1.2 - 2.3"}, want: want{false}},
+		{name: "not equal", in: in{"1.2.3", ">=1.0.0 !=1.2.3"}, want: want{false}},
+		{name: "prerelease excluded", in: in{"2.0.0-rc.1", ">=1.0.0"}, want: want{false}},
+		{name: "prerelease below caret", in: in{"2.0.0-rc.1", "^1.2.3"}, want: want{false}},
+		{name: "prerelease same tuple", in: in{"1.2.3-beta.3", "^1.2.3-beta.2"}, want: want{true}},
+		{name: "prerelease other tuple", in: in{"1.2.4-beta.3", "^1.2.3-beta.2"}, want: want{false}},
+		{name: "explicit zero prerelease", in: in{"1.2.3-alpha", ">=1.2.3-0"}, want: want{true}},
+		{name: "explicit zero prerelease exclusive", in: in{"1.2.3-alpha", ">1.2.3-0"}, want: want{true}},
+		{name: "explicit zero prerelease upper", in: in{"1.2.3-0", "<1.2.3-0"}, want: want{false}},
+		{name: "sentinel excludes prerelease", in: in{"1.3.0-alpha", ">1.2"}, want: want{false}},
+		{name: "sentinel upper excludes prerelease", in: in{"1.2.0-alpha", "<1.2"}, want: want{false}},
+		{name: "lenient version", in: in{"v1.4", "^1.2"}, want: want{true}},
+		{name: "invalid version", in: in{"1.4.x", "^1.2"}, want: want{false}},
+		{name: "invalid constraint", in: in{"1.4.0", ">=1.x.2"}, want: want{false}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validate.SemverSatisfies(tt.in.version, tt.in.constraint); got != tt.want.ok {
+				t.Errorf("SemverSatisfies(%q, %q) = %v, want %v", tt.in.version, tt.in.constraint, got, tt.want.ok)
+			}
+		})
+	}
+}
+
+func TestSemverParseConstraint(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "||", ">=", "~>1.2", "1.2.3.4", "!=1.2", ">=1.2.0 ||", "1.x.3", "1.2-rc.1"} {
+		if _, err := validate.SemverParseConstraint(s); !errors.Is(err, validate.ErrSemverConstraint) {
+			t.Errorf("SemverParseConstraint(%q) error = %v, want %v", s, err, validate.ErrSemverConstraint)
+		}
+	}
+}
+
+func TestGoModVersionIsValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{s: "v1.2.3", want: true},
+		{s: "v0.0.0-20191109021931-daa7c04131f5", want: true},
+		{s: "v2.0.0+incompatible", want: true},
+		{s: "v1.0.0+incompatible", want: false},
+		{s: "v2.0.0+build", want: false},
+		{s: "1.2.3", want: false},
+		{s: "v1.2", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := validate.GoModVersionIsValid(tt.s); got != tt.want {
+			t.Errorf("GoModVersionIsValid(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestGoPseudoVersionParse(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		base string
+		time time.Time
+		rev  string
+		err  bool
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{
+			name: "no base",
+			in:   "v0.0.0-20191109021931-daa7c04131f5",
+			want: want{base: "0.0.0", time: time.Date(2019, 11, 9, 2, 19, 31, 0, time.UTC), rev: "daa7c04131f5"},
+		},
+		{
+			name: "release base",
+			in:   "v1.2.4-0.20191109021931-daa7c04131f5",
+			want: want{base: "1.2.3", time: time.Date(2019, 11, 9, 2, 19, 31, 0, time.UTC), rev: "daa7c04131f5"},
+		},
+		{
+			name: "prerelease base",
+			in:   "v1.2.3-pre.0.20191109021931-daa7c04131f5",
+			want: want{base: "1.2.3-pre", time: time.Date(2019, 11, 9, 2, 19, 31, 0, time.UTC), rev: "daa7c04131f5"},
+		},
+		{
+			name: "incompatible",
+			in:   "v2.0.0-20191109021931-daa7c04131f5+incompatible",
+			want: want{base: "2.0.0", time: time.Date(2019, 11, 9, 2, 19, 31, 0, time.UTC), rev: "daa7c04131f5"},
+		},
+		{
+			name: "uppercase revision",
+			in:   "v0.0.0-20191109021931-DAA7C04131F5",
+			want: want{err: true},
+		},
+		{
+			name: "short revision",
+			in:   "v0.0.0-20191109021931-daa7c04",
+			want: want{err: true},
+		},
+		{
+			name: "invalid month",
+			in:   "v0.0.0-20191309021931-daa7c04131f5",
+			want: want{err: true},
+		},
+		{
+			name: "no base with minor",
+			in:   "v0.1.0-20191109021931-daa7c04131f5",
+			want: want{err: true},
+		},
+		{
+			name: "release",
+			in:   "v1.2.3",
+			want: want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := validate.GoPseudoVersionParse(tt.in)
+			if tt.want.err {
+				if !errors.Is(err, validate.ErrGoModVersion) {
+					t.Errorf("GoPseudoVersionParse(%q) error = %v, want %v", tt.in, err, validate.ErrGoModVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GoPseudoVersionParse(%q) error = %v", tt.in, err)
+			}
+			if p.Version.String() != tt.want.base || !p.Time.Equal(tt.want.time) || p.Revision != tt.want.rev {
+				t.Errorf("GoPseudoVersionParse(%q) = {%s %v %s}, want {%s %v %s}",
+					tt.in, p.Version, p.Time, p.Revision, tt.want.base, tt.want.time, tt.want.rev)
+			}
+		})
+	}
+}
+
+func TestSemverRules(t *testing.T) {
+	t.Parallel()
+
+	type plugin struct {
+		Version string `validate:"semver"`
+		API     string `validate:"semver_satisfies=>=1.2.0 <2.0.0"`
+		Module  string `validate:"gomod_version"`
+	}
+
+	if err := validate.ValidateStruct(plugin{Version: "1.0.0", API: "1.4.0", Module: "v1.0.0"}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+	if err := validate.ValidateStruct(plugin{Version: "1.0.0", API: "2.0.0", Module: "v1.0.0"}); !errors.Is(err, validate.ErrSemverUnsatisfied) {
+		t.Errorf("ValidateStruct() error = %v, want %v", err, validate.ErrSemverUnsatisfied)
+	}
+}
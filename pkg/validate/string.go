@@ -23,18 +23,93 @@
 package validate
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// LengthMode selects the unit in which the length of a string is measured.
+type LengthMode int
+
+const (
+	// LengthGraphemes counts extended grapheme clusters (UAX #29), the
+	// characters a user perceives: "é" written with a combining accent and
+	// a family emoji both count as one.
+	LengthGraphemes LengthMode = iota
+	// LengthBytes counts UTF-8 bytes, which is what storage limits such as
+	// column sizes are expressed in.
+	LengthBytes
+	LengthRunes
+	// LengthWidth counts monospace display columns (UAX #11). Wide East
+	// Asian characters and emoji take two columns, combining marks none.
+	LengthWidth
+)
+
+var lengthModeNames = []string{
+	LengthGraphemes: "graphemes",
+	LengthBytes:     "bytes",
+	LengthRunes:     "runes",
+	LengthWidth:     "width",
+}
+
+var (
+	ErrStringLength      = errors.New("invalid string length")
+	ErrStringTooShort    = fmt.Errorf("%w: too short", ErrStringLength)
+	ErrStringTooLong     = fmt.Errorf("%w: too long", ErrStringLength)
+	ErrLengthModeInvalid = errors.New("invalid length mode")
 )
 
+func init() {
+	RegisterRule("min_len", func(param string) (ValidationRule, error) {
+		n, mode, err := parseLengthParam(param)
+		if err != nil {
+			return nil, err
+		}
+		return StringLengthRule(n, -1, mode), nil
+	})
+	RegisterRule("max_len", func(param string) (ValidationRule, error) {
+		n, mode, err := parseLengthParam(param)
+		if err != nil {
+			return nil, err
+		}
+		return StringLengthRule(0, n, mode), nil
+	})
+}
+
+func (m LengthMode) String() string {
+	if m >= 0 && int(m) < len(lengthModeNames) {
+		return lengthModeNames[m]
+	}
+	return "LengthMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// ParseLengthMode parses the name of a length mode: "graphemes", "bytes",
+// "runes" or "width".
+func ParseLengthMode(s string) (LengthMode, error) {
+	for m, name := range lengthModeNames {
+		if s == name {
+			return LengthMode(m), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrLengthModeInvalid, s)
+}
+
 func StringIsEmpty(s string) bool {
 	return len(strings.TrimSpace(s)) == 0
 }
 
+// StringMinLength counts bytes. Use StringLengthBetween to measure in
+// runes, graphemes or display width.
 func StringMinLength(s string, min int) bool {
 	return len(s) >= min
 }
 
+// StringMaxLength counts bytes, see StringMinLength.
 func StringMaxLength(s string, max int) bool {
 	return len(s) <= max
 }
@@ -52,3 +127,62 @@ func StringContainsChars(s string, chars string) bool {
 	}
 	return true
 }
+
+func StringLength(s string, mode LengthMode) int {
+	switch mode {
+	case LengthBytes:
+		return len(s)
+	case LengthRunes:
+		return utf8.RuneCountInString(s)
+	case LengthWidth:
+		return uniseg.StringWidth(s)
+	default:
+		return uniseg.GraphemeClusterCount(s)
+	}
+}
+
+// StringLengthBetween checks if the length of s in the given mode is
+// within [min, max]. A negative max means there is no upper bound.
+func StringLengthBetween(s string, min, max int, mode LengthMode) bool {
+	return StringLengthValidate(s, min, max, mode) == nil
+}
+
+func StringLengthValidate(s string, min, max int, mode LengthMode) error {
+	n := StringLength(s, mode)
+	if n < min {
+		return fmt.Errorf("%w: %d %s, minimum is %d", ErrStringTooShort, n, mode, min)
+	}
+	if max >= 0 && n > max {
+		return fmt.Errorf("%w: %d %s, maximum is %d", ErrStringTooLong, n, mode, max)
+	}
+	return nil
+}
+
+// StringLengthRule returns a rule checking string lengths like
+// StringLengthValidate.
+func StringLengthRule(min, max int, mode LengthMode) ValidationRule {
+	return func(value interface{}) error {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+		}
+		return StringLengthValidate(s, min, max, mode)
+	}
+}
+
+// parseLengthParam parses rule parameters such as "20" or "20|bytes".
+// The mode defaults to graphemes.
+func parseLengthParam(param string) (int, LengthMode, error) {
+	num, name, hasMode := strings.Cut(param, "|")
+	n, err := strconv.Atoi(num)
+	if err != nil || n < 0 {
+		return 0, 0, fmt.Errorf("%w: length %q", ErrStringLength, num)
+	}
+	mode := LengthGraphemes
+	if hasMode {
+		if mode, err = ParseLengthMode(name); err != nil {
+			return 0, 0, err
+		}
+	}
+	return n, mode, nil
+}
@@ -0,0 +1,181 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrStringCharacters   = errors.New("string contains invalid characters")
+	ErrStringNotAlpha     = fmt.Errorf("%w: not alphabetic", ErrStringCharacters)
+	ErrStringNotAlnum     = fmt.Errorf("%w: not alphanumeric", ErrStringCharacters)
+	ErrStringNotNumeric   = fmt.Errorf("%w: not numeric", ErrStringCharacters)
+	ErrStringNotASCII     = fmt.Errorf("%w: not ASCII", ErrStringCharacters)
+	ErrStringNotPrintable = fmt.Errorf("%w: not printable", ErrStringCharacters)
+	ErrStringControl      = fmt.Errorf("%w: control character", ErrStringCharacters)
+	ErrStringNotLower     = fmt.Errorf("%w: not lowercase", ErrStringCharacters)
+	ErrStringNotUpper     = fmt.Errorf("%w: not uppercase", ErrStringCharacters)
+	ErrStringScript       = fmt.Errorf("%w: script not allowed", ErrStringCharacters)
+	ErrStringMissingChars = fmt.Errorf("%w: none of the required characters", ErrStringCharacters)
+	ErrStringForbidden    = fmt.Errorf("%w: forbidden character", ErrStringCharacters)
+	ErrScriptUnknown      = errors.New("unknown Unicode script")
+)
+
+func init() {
+	RegisterRule("alpha", stringRuleFactory(StringIsAlpha, ErrStringNotAlpha))
+	RegisterRule("alnum", stringRuleFactory(StringIsAlnum, ErrStringNotAlnum))
+	RegisterRule("numeric", stringRuleFactory(StringIsNumeric, ErrStringNotNumeric))
+	RegisterRule("ascii", stringRuleFactory(StringIsASCII, ErrStringNotASCII))
+	RegisterRule("printable", stringRuleFactory(StringIsPrintable, ErrStringNotPrintable))
+	RegisterRule("no_control", stringRuleFactory(StringHasNoControl, ErrStringControl))
+	RegisterRule("lowercase", stringRuleFactory(StringIsLower, ErrStringNotLower))
+	RegisterRule("uppercase", stringRuleFactory(StringIsUpper, ErrStringNotUpper))
+	RegisterRule("scripts", func(param string) (ValidationRule, error) {
+		scripts := strings.Split(param, "|")
+		for _, name := range scripts {
+			if _, ok := unicode.Scripts[name]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrScriptUnknown, name)
+			}
+		}
+		return StringRule(func(s string) bool { return StringIsInScripts(s, scripts...) }, ErrStringScript), nil
+	})
+	RegisterRule("contains_any", func(param string) (ValidationRule, error) {
+		return StringRule(func(s string) bool { return StringContainsAny(s, param) }, ErrStringMissingChars), nil
+	})
+	RegisterRule("contains_none", func(param string) (ValidationRule, error) {
+		return StringRule(func(s string) bool { return StringContainsNone(s, param) }, ErrStringForbidden), nil
+	})
+}
+
+// StringIsAlpha checks if s is non-empty and consists of letters of any
+// script. Combining marks are accepted after a letter, so decomposed
+// accents such as "é" pass.
+func StringIsAlpha(s string) bool {
+	return stringAll(s, unicode.IsLetter)
+}
+
+// StringIsAlnum is like StringIsAlpha but also accepts decimal digits of
+// any script.
+func StringIsAlnum(s string) bool {
+	return stringAll(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	})
+}
+
+// StringIsNumeric checks if s is non-empty and consists of decimal digits
+// of any script, e.g. "123" or "١٢٣". Signs and separators are rejected.
+func StringIsNumeric(s string) bool {
+	return s != "" && utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	}) < 0
+}
+
+func StringIsASCII(s string) bool {
+	return isASCII(s)
+}
+
+// StringIsPrintable checks if s is valid UTF-8 consisting of letters,
+// marks, numbers, punctuation, symbols and spaces. Control and format
+// characters, unassigned and private use code points are rejected.
+func StringIsPrintable(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsGraphic(r)
+	}) < 0
+}
+
+// StringHasNoControl checks if s is valid UTF-8 without C0 or C1 control
+// characters, including tabs and newlines.
+func StringHasNoControl(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, unicode.IsControl) < 0
+}
+
+// StringIsLower checks if s contains no uppercase or titlecase letters.
+// Uncased characters such as digits are ignored.
+func StringIsLower(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsUpper(r) || unicode.IsTitle(r)
+	}) < 0
+}
+
+// StringIsUpper checks if s contains no lowercase or titlecase letters.
+// Uncased characters such as digits are ignored.
+func StringIsUpper(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLower(r) || unicode.IsTitle(r)
+	}) < 0
+}
+
+// StringIsInScripts checks if every character of s belongs to one of the
+// named Unicode scripts, e.g. "Latin" and "Cyrillic". Characters shared
+// between scripts (Common, such as digits and punctuation) and combining
+// marks that inherit the script of their base (Inherited) are always
+// accepted. Unknown script names never match.
+func StringIsInScripts(s string, scripts ...string) bool {
+	tables := make([]*unicode.RangeTable, 0, len(scripts)+2)
+	for _, name := range scripts {
+		table, ok := unicode.Scripts[name]
+		if !ok {
+			return false
+		}
+		tables = append(tables, table)
+	}
+	tables = append(tables, unicode.Common, unicode.Inherited)
+
+	return utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.In(r, tables...)
+	}) < 0
+}
+
+// StringContainsAny checks if s contains at least one of the characters
+// in chars, unlike StringContainsChars which requires all of them.
+func StringContainsAny(s string, chars string) bool {
+	return strings.ContainsAny(s, chars)
+}
+
+func StringContainsNone(s string, chars string) bool {
+	return !strings.ContainsAny(s, chars)
+}
+
+// stringAll checks if s is non-empty valid UTF-8 where every rune matches
+// fn, allowing combining marks after a matching rune.
+func stringAll(s string, fn func(rune) bool) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+	prev := false
+	for _, r := range s {
+		switch {
+		case fn(r):
+			prev = true
+		case prev && unicode.IsMark(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
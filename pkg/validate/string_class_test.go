@@ -0,0 +1,332 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestStringCharacterClasses(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		alpha     bool
+		alnum     bool
+		numeric   bool
+		ascii     bool
+		printable bool
+		noControl bool
+		lower     bool
+		upper     bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ascii letters",
+			in:   in{s: "hello"},
+			want: want{alpha: true, alnum: true, ascii: true, printable: true, noControl: true, lower: true},
+		},
+		{
+			name: "mixed case",
+			in:   in{s: "Hello"},
+			want: want{alpha: true, alnum: true, ascii: true, printable: true, noControl: true},
+		},
+		{
+			name: "uppercase with digits",
+			in:   in{s: "ABC123"},
+			want: want{alnum: true, ascii: true, printable: true, noControl: true, upper: true},
+		},
+		{
+			name: "digits",
+			in:   in{s: "123"},
+			want: want{alnum: true, numeric: true, ascii: true, printable: true, noControl: true, lower: true, upper: true},
+		},
+		{
+			name: "arabic-indic digits",
+			in:   in{s: "١٢٣"},
+			want: want{alnum: true, numeric: true, printable: true, noControl: true, lower: true, upper: true},
+		},
+		{
+			name: "decomposed accent",
+			in:   in{s: "cafe\u0301"},
+			want: want{alpha: true, alnum: true, printable: true, noControl: true, lower: true},
+		},
+		{
+			name: "leading combining mark",
+			in:   in{s: "\u0301a"},
+			want: want{printable: true, noControl: true, lower: true},
+		},
+		{
+			name: "greek and cyrillic",
+			in:   in{s: "\u03b1\u0431"},
+			want: want{alpha: true, alnum: true, printable: true, noControl: true, lower: true},
+		},
+		{
+			name: "titlecase digraph",
+			in:   in{s: "\u01c5"},
+			want: want{alpha: true, alnum: true, printable: true, noControl: true},
+		},
+		{
+			name: "space",
+			in:   in{s: "hello world"},
+			want: want{ascii: true, printable: true, noControl: true, lower: true},
+		},
+		{
+			name: "newline",
+			in:   in{s: "hello\n"},
+			want: want{ascii: true, lower: true},
+		},
+		{
+			name: "c1 control",
+			in:   in{s: "hello\u0085"},
+			want: want{lower: true},
+		},
+		{
+			name: "zero width joiner",
+			in:   in{s: "a\u200db"},
+			want: want{noControl: true, lower: true},
+		},
+		{
+			name: "invalid utf-8",
+			in:   in{s: "a\xffb"},
+			want: want{},
+		},
+		{
+			name: "empty",
+			in:   in{s: ""},
+			want: want{ascii: true, printable: true, noControl: true, lower: true, upper: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := want{
+				alpha:     validate.StringIsAlpha(tt.in.s),
+				alnum:     validate.StringIsAlnum(tt.in.s),
+				numeric:   validate.StringIsNumeric(tt.in.s),
+				ascii:     validate.StringIsASCII(tt.in.s),
+				printable: validate.StringIsPrintable(tt.in.s),
+				noControl: validate.StringHasNoControl(tt.in.s),
+				lower:     validate.StringIsLower(tt.in.s),
+				upper:     validate.StringIsUpper(tt.in.s),
+			}
+			if got != tt.want {
+				t.Errorf("classes of %q = %+v, want %+v", tt.in.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringIsInScripts(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s       string
+		scripts []string
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "latin",
+			in:   in{s: "paypal", scripts: []string{"Latin"}},
+			want: want{result: true},
+		},
+		{
+			name: "cyrillic letter in latin",
+			in:   in{s: "p\u0430ypal", scripts: []string{"Latin"}},
+			want: want{result: false},
+		},
+		{
+			name: "latin and cyrillic",
+			in:   in{s: "p\u0430ypal", scripts: []string{"Latin", "Cyrillic"}},
+			want: want{result: true},
+		},
+		{
+			name: "common characters",
+			in:   in{s: "user_42-test.", scripts: []string{"Latin"}},
+			want: want{result: true},
+		},
+		{
+			name: "inherited combining mark",
+			in:   in{s: "cafe\u0301", scripts: []string{"Latin"}},
+			want: want{result: true},
+		},
+		{
+			name: "han",
+			in:   in{s: "漢字", scripts: []string{"Latin"}},
+			want: want{result: false},
+		},
+		{
+			name: "unknown script",
+			in:   in{s: "abc", scripts: []string{"Klingon"}},
+			want: want{result: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validate.StringIsInScripts(tt.in.s, tt.in.scripts...)
+			if got != tt.want.result {
+				t.Errorf("StringIsInScripts(%q, %q) = %v, want %v", tt.in.s, tt.in.scripts, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestStringContainsAny(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s     string
+		chars string
+	}
+
+	type want struct {
+		any  bool
+		none bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "one present",
+			in:   in{s: "pa$$word", chars: "!@#$"},
+			want: want{any: true, none: false},
+		},
+		{
+			name: "none present",
+			in:   in{s: "password", chars: "!@#$"},
+			want: want{any: false, none: true},
+		},
+		{
+			name: "non-ascii",
+			in:   in{s: "grüße", chars: "äöü"},
+			want: want{any: true, none: false},
+		},
+		{
+			name: "empty set",
+			in:   in{s: "abc", chars: ""},
+			want: want{any: false, none: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.StringContainsAny(tt.in.s, tt.in.chars); got != tt.want.any {
+				t.Errorf("StringContainsAny(%q, %q) = %v, want %v", tt.in.s, tt.in.chars, got, tt.want.any)
+			}
+			if got := validate.StringContainsNone(tt.in.s, tt.in.chars); got != tt.want.none {
+				t.Errorf("StringContainsNone(%q, %q) = %v, want %v", tt.in.s, tt.in.chars, got, tt.want.none)
+			}
+		})
+	}
+}
+
+func TestStringCharacterRules(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag   string
+		value string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "alnum",
+			in:   in{tag: "alnum", value: "user42"},
+			want: want{err: nil},
+		},
+		{
+			name: "alnum with space",
+			in:   in{tag: "alnum", value: "user 42"},
+			want: want{err: validate.ErrStringNotAlnum},
+		},
+		{
+			name: "scripts",
+			in:   in{tag: "scripts=Latin|Cyrillic", value: "p\u0430ypal"},
+			want: want{err: nil},
+		},
+		{
+			name: "scripts with greek",
+			in:   in{tag: "scripts=Latin", value: "α"},
+			want: want{err: validate.ErrStringScript},
+		},
+		{
+			name: "contains any",
+			in:   in{tag: "contains_any=0123456789", value: "secret"},
+			want: want{err: validate.ErrStringMissingChars},
+		},
+		{
+			name: "contains none",
+			in:   in{tag: "contains_none=<>", value: "<b>"},
+			want: want{err: validate.ErrStringForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validate.NewValidate()
+			if err := v.AddNamedRule(tt.in.tag); err != nil {
+				t.Fatalf("AddNamedRule(%q) error = %v", tt.in.tag, err)
+			}
+			err := v.Validate(tt.in.value)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("Validate(%q) = %v, want %v", tt.in.value, err, tt.want.err)
+			}
+		})
+	}
+
+	if err := validate.NewValidate().AddNamedRule("scripts=Klingon"); !errors.Is(err, validate.ErrScriptUnknown) {
+		t.Errorf("AddNamedRule(scripts=Klingon) error = %v, want %v", err, validate.ErrScriptUnknown)
+	}
+}
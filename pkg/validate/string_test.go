@@ -23,6 +23,7 @@
 package validate_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/progxeno/validate/pkg/validate"
@@ -306,3 +307,192 @@ func TestStringContainsChars(t *testing.T) {
 		})
 	}
 }
+
+func TestStringLength(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		bytes     int
+		runes     int
+		graphemes int
+		width     int
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ascii",
+			in:   in{s: "hello"},
+			want: want{bytes: 5, runes: 5, graphemes: 5, width: 5},
+		},
+		{
+			name: "precomposed accent",
+			in:   in{s: "h\u00e9llo"},
+			want: want{bytes: 6, runes: 5, graphemes: 5, width: 5},
+		},
+		{
+			name: "combining accent",
+			in:   in{s: "he\u0301llo"},
+			want: want{bytes: 7, runes: 6, graphemes: 5, width: 5},
+		},
+		{
+			name: "emoji family",
+			in:   in{s: "\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466"},
+			want: want{bytes: 25, runes: 7, graphemes: 1, width: 2},
+		},
+		{
+			name: "flag",
+			in:   in{s: "\U0001F1E9\U0001F1EA"},
+			want: want{bytes: 8, runes: 2, graphemes: 1, width: 2},
+		},
+		{
+			name: "east asian wide",
+			in:   in{s: "\u65e5\u672c\u8a9e"},
+			want: want{bytes: 9, runes: 3, graphemes: 3, width: 6},
+		},
+		{
+			name: "empty",
+			in:   in{s: ""},
+			want: want{bytes: 0, runes: 0, graphemes: 0, width: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := want{
+				bytes:     validate.StringLength(tt.in.s, validate.LengthBytes),
+				runes:     validate.StringLength(tt.in.s, validate.LengthRunes),
+				graphemes: validate.StringLength(tt.in.s, validate.LengthGraphemes),
+				width:     validate.StringLength(tt.in.s, validate.LengthWidth),
+			}
+			if got != tt.want {
+				t.Errorf("StringLength(%q) = %+v, want %+v", tt.in.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringLengthValidate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		min  int
+		max  int
+		mode validate.LengthMode
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "within graphemes",
+			in:   in{s: "h\u00e9llo", min: 1, max: 5, mode: validate.LengthGraphemes},
+			want: want{err: nil},
+		},
+		{
+			name: "too long in bytes",
+			in:   in{s: "h\u00e9llo", min: 1, max: 5, mode: validate.LengthBytes},
+			want: want{err: validate.ErrStringTooLong},
+		},
+		{
+			name: "too short",
+			in:   in{s: "ab", min: 3, max: 10, mode: validate.LengthRunes},
+			want: want{err: validate.ErrStringTooShort},
+		},
+		{
+			name: "no maximum",
+			in:   in{s: "abcdefghij", min: 3, max: -1, mode: validate.LengthGraphemes},
+			want: want{err: nil},
+		},
+		{
+			name: "too wide",
+			in:   in{s: "\u65e5\u672c\u8a9e", min: 0, max: 5, mode: validate.LengthWidth},
+			want: want{err: validate.ErrStringTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.StringLengthValidate(tt.in.s, tt.in.min, tt.in.max, tt.in.mode)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("StringLengthValidate(%q, %d, %d, %v) = %v, want %v", tt.in.s, tt.in.min, tt.in.max, tt.in.mode, err, tt.want.err)
+			}
+			if got := validate.StringLengthBetween(tt.in.s, tt.in.min, tt.in.max, tt.in.mode); got != (tt.want.err == nil) {
+				t.Errorf("StringLengthBetween(%q) = %v, want %v", tt.in.s, got, tt.want.err == nil)
+			}
+		})
+	}
+}
+
+func TestStringLengthRules(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag   string
+		value string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "max graphemes by default",
+			in:   in{tag: "max_len=1", value: "\U0001F1E9\U0001F1EA"},
+			want: want{err: nil},
+		},
+		{
+			name: "max bytes",
+			in:   in{tag: "max_len=1|bytes", value: "\U0001F1E9\U0001F1EA"},
+			want: want{err: validate.ErrStringTooLong},
+		},
+		{
+			name: "min runes",
+			in:   in{tag: "min_len=3|runes", value: "ab"},
+			want: want{err: validate.ErrStringTooShort},
+		},
+		{
+			name: "max width",
+			in:   in{tag: "max_len=4|width", value: "\u65e5\u672c"},
+			want: want{err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validate.NewValidate()
+			if err := v.AddNamedRule(tt.in.tag); err != nil {
+				t.Fatalf("AddNamedRule(%q) error = %v", tt.in.tag, err)
+			}
+			err := v.Validate(tt.in.value)
+			if tt.want.err == nil && err != nil || tt.want.err != nil && !errors.Is(err, tt.want.err) {
+				t.Errorf("Validate(%q) = %v, want %v", tt.in.value, err, tt.want.err)
+			}
+		})
+	}
+
+	for _, tag := range []string{"max_len", "max_len=-1", "min_len=3|glyphs"} {
+		if err := validate.NewValidate().AddNamedRule(tag); err == nil {
+			t.Errorf("AddNamedRule(%q) error = nil, want error", tag)
+		}
+	}
+}
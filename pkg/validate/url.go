@@ -23,10 +23,379 @@
 package validate
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	ErrURLInvalid      = errors.New("invalid URL")
+	ErrURLTooLong      = errors.New("URL too long")
+	ErrURLNotAbsolute  = errors.New("URL is not absolute")
+	ErrURLNotRelative  = errors.New("URL is not relative")
+	ErrURLScheme       = errors.New("URL scheme not allowed")
+	ErrURLNotTLS       = errors.New("URL scheme does not use TLS")
+	ErrURLHostRequired = errors.New("URL host required")
+	ErrURLUserinfo     = errors.New("URL must not contain credentials")
+	ErrURLPort         = errors.New("URL port not allowed")
+	ErrURLFragment     = errors.New("URL must not contain a fragment")
 )
 
+var urlDefaultPorts = map[string]int{
+	"ftp":   21,
+	"ftps":  990,
+	"http":  80,
+	"https": 443,
+	"ws":    80,
+	"wss":   443,
+}
+
+var urlTLSSchemes = map[string]bool{
+	"ftps":  true,
+	"https": true,
+	"imaps": true,
+	"ldaps": true,
+	"pop3s": true,
+	"smtps": true,
+	"wss":   true,
+}
+
+type URLNormalization int
+
+const (
+	// URLNormalizeSyntax applies the syntax-based normalizations of
+	// RFC 3986, section 6.2.2: case-folding the scheme and host,
+	// normalizing percent-encodings and removing dot segments.
+	URLNormalizeSyntax URLNormalization = iota
+	// URLNormalizeScheme additionally applies the scheme-based
+	// normalizations of section 6.2.3: removing default and empty ports,
+	// using "/" for an empty path, and converting IDN hosts to Punycode.
+	URLNormalizeScheme
+	// URLNormalizeAggressive additionally sorts query parameters, drops an
+	// empty query and drops the fragment. The result may identify a
+	// different resource for servers that depend on parameter order.
+	URLNormalizeAggressive
+)
+
+type URLReference int
+
+const (
+	// URLAnyReference accepts both absolute URLs and relative references.
+	URLAnyReference URLReference = iota
+	URLAbsolute
+	URLRelative
+)
+
+type URLPortRange struct {
+	Min int
+	Max int
+}
+
+type URLOptions struct {
+	// AllowedSchemes lists the accepted schemes, compared case-insensitively.
+	// An empty list accepts any scheme.
+	AllowedSchemes []string
+	RequireHost    bool
+	ForbidUserinfo bool
+	// AllowedPorts restricts the explicit port, or the scheme's default
+	// port if none is given. An empty list accepts any port.
+	AllowedPorts   []URLPortRange
+	RequireTLS     bool
+	ForbidFragment bool
+	// MaxLength limits the length of the URL in bytes; 0 means no limit.
+	MaxLength int
+	Reference URLReference
+}
+
 func URLIsValid(urlStr string) bool {
 	u, err := url.Parse(urlStr)
 	return err == nil && u.Scheme != ""
 }
+
+// URLValidate checks urlStr against opts and returns an error wrapping
+// the sentinel of the first violated option.
+func URLValidate(urlStr string, opts URLOptions) error {
+	if opts.MaxLength > 0 && len(urlStr) > opts.MaxLength {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrURLTooLong, len(urlStr), opts.MaxLength)
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrURLInvalid, err)
+	}
+
+	switch {
+	case opts.Reference == URLAbsolute && !u.IsAbs():
+		return ErrURLNotAbsolute
+	case opts.Reference == URLRelative && u.IsAbs():
+		return ErrURLNotRelative
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if len(opts.AllowedSchemes) > 0 && !urlSchemeAllowed(scheme, opts.AllowedSchemes) {
+		return fmt.Errorf("%w: %q", ErrURLScheme, u.Scheme)
+	}
+	if opts.RequireTLS && !urlTLSSchemes[scheme] {
+		return fmt.Errorf("%w: %q", ErrURLNotTLS, u.Scheme)
+	}
+	if opts.RequireHost && u.Hostname() == "" {
+		return ErrURLHostRequired
+	}
+	if opts.ForbidUserinfo && u.User != nil {
+		return ErrURLUserinfo
+	}
+	if err := urlValidatePort(u, scheme, opts.AllowedPorts); err != nil {
+		return err
+	}
+	if opts.ForbidFragment && strings.Contains(urlStr, "#") {
+		return ErrURLFragment
+	}
+	return nil
+}
+
+func urlSchemeAllowed(scheme string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(scheme, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func urlValidatePort(u *url.URL, scheme string, allowed []URLPortRange) error {
+	port, explicit := urlDefaultPorts[scheme], false
+	if p := u.Port(); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n > 65535 {
+			return fmt.Errorf("%w: %q out of range", ErrURLPort, p)
+		}
+		port, explicit = n, true
+	}
+	if len(allowed) == 0 || !explicit && port == 0 {
+		return nil
+	}
+
+	for _, r := range allowed {
+		if port >= r.Min && port <= r.Max {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", ErrURLPort, port)
+}
+
+// URLNormalize returns urlStr normalized at the given level.
+func URLNormalize(urlStr string, level URLNormalization) (string, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrURLInvalid, err)
+	}
+
+	var b strings.Builder
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "" {
+		b.WriteString(scheme)
+		b.WriteByte(':')
+	}
+
+	if u.Opaque != "" {
+		b.WriteString(urlNormalizePercent(u.Opaque))
+	} else {
+		hasAuthority := u.Host != "" || u.User != nil ||
+			strings.HasPrefix(strings.TrimPrefix(urlStr[len(u.Scheme):], ":"), "//")
+		if hasAuthority {
+			host, err := urlNormalizeHost(u, scheme, level)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString("//")
+			if u.User != nil {
+				b.WriteString(urlNormalizePercent(u.User.String()))
+				b.WriteByte('@')
+			}
+			b.WriteString(host)
+		}
+
+		// Dot segments of a relative-path reference are resolved against
+		// a base URL, so they must be kept (RFC 3986, section 5.2).
+		path := urlNormalizePercent(u.EscapedPath())
+		if scheme != "" || hasAuthority || strings.HasPrefix(path, "/") {
+			path = urlRemoveDotSegments(path)
+		}
+		if path == "" && hasAuthority && level >= URLNormalizeScheme {
+			path = "/"
+		}
+		b.WriteString(path)
+	}
+
+	query := urlNormalizePercent(u.RawQuery)
+	if level >= URLNormalizeAggressive {
+		query = urlSortQuery(query)
+	}
+	if query != "" || u.ForceQuery && level < URLNormalizeAggressive {
+		b.WriteByte('?')
+		b.WriteString(query)
+	}
+
+	if level < URLNormalizeAggressive && strings.Contains(urlStr, "#") {
+		b.WriteByte('#')
+		b.WriteString(urlNormalizePercent(u.EscapedFragment()))
+	}
+	return b.String(), nil
+}
+
+// URLEquivalent reports whether a and b are equivalent after scheme-based
+// normalization. Invalid URLs are never equivalent.
+func URLEquivalent(a string, b string) bool {
+	na, err := URLNormalize(a, URLNormalizeScheme)
+	if err != nil {
+		return false
+	}
+	nb, err := URLNormalize(b, URLNormalizeScheme)
+	return err == nil && na == nb
+}
+
+func urlNormalizeHost(u *url.URL, scheme string, level URLNormalization) (string, error) {
+	host, port := u.Hostname(), u.Port()
+	if level >= URLNormalizeScheme {
+		if !isASCII(host) {
+			ascii, err := idna.Lookup.ToASCII(host)
+			if err != nil {
+				return "", fmt.Errorf("%w: %v", ErrURLInvalid, err)
+			}
+			host = ascii
+		}
+		if p, err := strconv.Atoi(port); err == nil && urlIsDefaultPort(scheme, p) {
+			port = ""
+		}
+	}
+
+	host = strings.ToLower(host)
+	if strings.Contains(host, ":") {
+		// IPv6 literal, whose zone identifier must be escaped again.
+		host = "[" + strings.Replace(host, "%", "%25", 1) + "]"
+	} else {
+		host = urlNormalizePercent(host)
+	}
+	if port != "" {
+		return net.JoinHostPort(strings.Trim(host, "[]"), port), nil
+	}
+	if level < URLNormalizeScheme && strings.HasSuffix(u.Host, ":") {
+		host += ":"
+	}
+	return host, nil
+}
+
+// urlNormalizePercent decodes percent-encoded unreserved characters and
+// uppercases the hexadecimal digits of all other percent-encodings.
+func urlNormalizePercent(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if urlIsUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+		}
+		i += 2
+	}
+	return b.String()
+}
+
+// urlRemoveDotSegments implements RFC 3986, section 5.2.4.
+func urlRemoveDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	var out []string
+	in := path
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "/..":
+			in = "/"
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "." || in == "..":
+			in = ""
+		default:
+			i := strings.IndexByte(in[1:], '/')
+			if i < 0 {
+				out = append(out, in)
+				in = ""
+			} else {
+				out = append(out, in[:i+1])
+				in = in[i+1:]
+			}
+		}
+	}
+	return strings.Join(out, "")
+}
+
+func urlSortQuery(query string) string {
+	if query == "" {
+		return ""
+	}
+	params := strings.Split(query, "&")
+	sort.SliceStable(params, func(i, j int) bool {
+		ki, _, _ := strings.Cut(params[i], "=")
+		kj, _, _ := strings.Cut(params[j], "=")
+		return ki < kj
+	})
+	return strings.Join(params, "&")
+}
+
+func urlIsUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// urlIsDefaultPort reports whether port is the default port of scheme.
+// Schemes without a known default port have none, not port 0.
+func urlIsDefaultPort(scheme string, port int) bool {
+	def, ok := urlDefaultPorts[scheme]
+	return ok && port == def
+}
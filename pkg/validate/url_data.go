@@ -0,0 +1,213 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const dataURISniffLen = 512
+
+var (
+	ErrDataURI                = errors.New("invalid data URI")
+	ErrDataURIMediaType       = errors.New("data URI media type not allowed")
+	ErrDataURIEncoding        = errors.New("invalid data URI encoding")
+	ErrDataURITooLarge        = errors.New("data URI payload too large")
+	ErrDataURIContentMismatch = errors.New("data URI content does not match its media type")
+)
+
+// DataURI describes a data URI (RFC 2397).
+type DataURI struct {
+	// MediaType is the lowercased media type without parameters.
+	MediaType string
+	Params    map[string]string
+	Base64    bool
+	// Size is the length of the decoded payload in bytes.
+	Size int64
+}
+
+type DataURIOptions struct {
+	// AllowedMediaTypes lists the accepted media types. Entries such as
+	// "image/*" accept any subtype. An empty list accepts any media type.
+	AllowedMediaTypes []string
+	// MaxSize limits the decoded payload in bytes; 0 means no limit.
+	MaxSize int64
+	// Sniff checks that the decoded payload looks like the declared
+	// media type, using the algorithm of http.DetectContentType. It is
+	// meant for formats the algorithm recognizes, such as images.
+	Sniff bool
+}
+
+func DataURIIsValid(s string) bool {
+	_, err := DataURIValidate(s, DataURIOptions{})
+	return err == nil
+}
+
+// DataURIValidate parses s and checks it against opts. The payload is
+// decoded in a streaming fashion so that it is never held in memory.
+func DataURIValidate(s string, opts DataURIOptions) (*DataURI, error) {
+	if len(s) < len("data:") || !strings.EqualFold(s[:len("data:")], "data:") {
+		return nil, fmt.Errorf("%w: missing data: scheme", ErrDataURI)
+	}
+	header, data, ok := strings.Cut(s[len("data:"):], ",")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing comma", ErrDataURI)
+	}
+
+	d, err := parseDataURIHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.AllowedMediaTypes) > 0 && !dataURIMediaTypeAllowed(d.MediaType, opts.AllowedMediaTypes) {
+		return nil, fmt.Errorf("%w: %s", ErrDataURIMediaType, d.MediaType)
+	}
+
+	var r io.Reader = &dataURIPercentReader{s: data}
+	if d.Base64 {
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+
+	var head []byte
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		d.Size += int64(n)
+		if opts.MaxSize > 0 && d.Size > opts.MaxSize {
+			return nil, fmt.Errorf("%w: exceeds %d bytes", ErrDataURITooLarge, opts.MaxSize)
+		}
+		if opts.Sniff && len(head) < dataURISniffLen {
+			head = append(head, buf[:minInt(n, dataURISniffLen-len(head))]...)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDataURIEncoding, err)
+		}
+	}
+
+	if opts.Sniff {
+		if sniffed := http.DetectContentType(head); !dataURISniffMatches(d.MediaType, sniffed) {
+			return nil, fmt.Errorf("%w: declared %s, detected %s", ErrDataURIContentMismatch, d.MediaType, sniffed)
+		}
+	}
+	return d, nil
+}
+
+func parseDataURIHeader(header string) (*DataURI, error) {
+	d := &DataURI{}
+	if i := strings.LastIndexByte(header, ';'); i >= 0 && strings.EqualFold(header[i+1:], "base64") {
+		d.Base64 = true
+		header = header[:i]
+	}
+
+	// RFC 2397 defaults to text/plain;charset=US-ASCII, and allows the
+	// type to be omitted while keeping parameters.
+	if header == "" {
+		header = "text/plain;charset=US-ASCII"
+	} else if strings.HasPrefix(header, ";") {
+		header = "text/plain" + header
+	}
+
+	unescaped, err := dataURIUnescape(header)
+	if err != nil {
+		return nil, err
+	}
+	mediaType, params, err := mime.ParseMediaType(unescaped)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDataURI, err)
+	}
+	d.MediaType, d.Params = mediaType, params
+	return d, nil
+}
+
+func dataURIMediaTypeAllowed(mediaType string, allowed []string) bool {
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType || a == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func dataURISniffMatches(declared string, sniffed string) bool {
+	sniffed, _, _ = strings.Cut(sniffed, ";")
+	switch {
+	case declared == sniffed:
+		return true
+	case sniffed == "text/plain":
+		return strings.HasPrefix(declared, "text/") || declared == "application/json"
+	case sniffed == "text/xml":
+		return declared == "application/xml" || strings.HasSuffix(declared, "+xml")
+	}
+	return false
+}
+
+func dataURIUnescape(s string) (string, error) {
+	b, err := io.ReadAll(&dataURIPercentReader{s: s})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDataURIEncoding, err)
+	}
+	return string(b), nil
+}
+
+// dataURIPercentReader decodes the percent-encoded data of a data URI.
+type dataURIPercentReader struct {
+	s string
+	i int
+}
+
+func (r *dataURIPercentReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && r.i < len(r.s) {
+		c := r.s[r.i]
+		switch {
+		case c == '%':
+			if r.i+2 >= len(r.s) || !isHex(r.s[r.i+1]) || !isHex(r.s[r.i+2]) {
+				return n, fmt.Errorf("invalid percent-encoding at offset %d", r.i)
+			}
+			p[n] = unhex(r.s[r.i+1])<<4 | unhex(r.s[r.i+2])
+			r.i += 3
+		case c <= ' ' || c >= 0x7f || strings.IndexByte(`"<>\^`+"`{|}", c) >= 0:
+			return n, fmt.Errorf("invalid character %q at offset %d", c, r.i)
+		default:
+			p[n] = c
+			r.i++
+		}
+		n++
+	}
+	if n == 0 && r.i >= len(r.s) {
+		return 0, io.EOF
+	}
+	return n, nil
+}
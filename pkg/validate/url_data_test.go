@@ -0,0 +1,191 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestDataURIValidate(t *testing.T) {
+	t.Parallel()
+
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+	gif := base64.StdEncoding.EncodeToString([]byte("GIF89a\x01\x00\x01\x00"))
+	images := validate.DataURIOptions{AllowedMediaTypes: []string{"image/png", "image/jpeg"}, MaxSize: 1024, Sniff: true}
+
+	type in struct {
+		uri  string
+		opts validate.DataURIOptions
+	}
+
+	type want struct {
+		result *validate.DataURI
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "png avatar",
+			in: in{
+				uri:  "data:image/png;base64," + png,
+				opts: images,
+			},
+			want: want{
+				result: &validate.DataURI{MediaType: "image/png", Params: map[string]string{}, Base64: true, Size: 16},
+				err:    nil,
+			},
+		},
+		{
+			name: "default media type",
+			in: in{
+				uri:  "data:,Hello%2C%20World!",
+				opts: validate.DataURIOptions{},
+			},
+			want: want{
+				result: &validate.DataURI{MediaType: "text/plain", Params: map[string]string{"charset": "US-ASCII"}, Size: 13},
+				err:    nil,
+			},
+		},
+		{
+			name: "parameters without type",
+			in: in{
+				uri:  "data:;charset=utf-8,%E2%9C%93",
+				opts: validate.DataURIOptions{AllowedMediaTypes: []string{"text/*"}},
+			},
+			want: want{
+				result: &validate.DataURI{MediaType: "text/plain", Params: map[string]string{"charset": "utf-8"}, Size: 3},
+				err:    nil,
+			},
+		},
+		{
+			name: "media type not allowed",
+			in: in{
+				uri:  "data:text/html;base64,PGgxPmhpPC9oMT4=",
+				opts: images,
+			},
+			want: want{
+				err: validate.ErrDataURIMediaType,
+			},
+		},
+		{
+			name: "content does not match media type",
+			in: in{
+				uri:  "data:image/png;base64," + gif,
+				opts: images,
+			},
+			want: want{
+				err: validate.ErrDataURIContentMismatch,
+			},
+		},
+		{
+			name: "too large",
+			in: in{
+				uri:  "data:image/png;base64," + base64.StdEncoding.EncodeToString(make([]byte, 2048)),
+				opts: images,
+			},
+			want: want{
+				err: validate.ErrDataURITooLarge,
+			},
+		},
+		{
+			name: "invalid base64",
+			in: in{
+				uri:  "data:image/png;base64,iVBO!!==",
+				opts: validate.DataURIOptions{},
+			},
+			want: want{
+				err: validate.ErrDataURIEncoding,
+			},
+		},
+		{
+			name: "invalid percent-encoding",
+			in: in{
+				uri:  "data:,100%zz",
+				opts: validate.DataURIOptions{},
+			},
+			want: want{
+				err: validate.ErrDataURIEncoding,
+			},
+		},
+		{
+			name: "missing comma",
+			in: in{
+				uri:  "data:image/png;base64",
+				opts: validate.DataURIOptions{},
+			},
+			want: want{
+				err: validate.ErrDataURI,
+			},
+		},
+		{
+			name: "not a data URI",
+			in: in{
+				uri:  "https://example.com/avatar.png",
+				opts: validate.DataURIOptions{},
+			},
+			want: want{
+				err: validate.ErrDataURI,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.DataURIValidate(tt.in.uri, tt.in.opts)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("DataURIValidate(%q) error = %v, want %v", tt.in.uri, err, tt.want.err)
+			}
+			if tt.want.result == nil {
+				return
+			}
+			if got.MediaType != tt.want.result.MediaType || got.Base64 != tt.want.result.Base64 || got.Size != tt.want.result.Size {
+				t.Errorf("DataURIValidate(%q) = %+v, want %+v", tt.in.uri, got, tt.want.result)
+			}
+			for k, v := range tt.want.result.Params {
+				if got.Params[k] != v {
+					t.Errorf("DataURIValidate(%q) param %s = %q, want %q", tt.in.uri, k, got.Params[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestDataURIIsValid(t *testing.T) {
+	t.Parallel()
+
+	if !validate.DataURIIsValid("data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 100000)))) {
+		t.Errorf("DataURIIsValid() = false for large payload, want true")
+	}
+	if validate.DataURIIsValid("data:text/plain,a b") {
+		t.Errorf("DataURIIsValid() = true for unencoded space, want false")
+	}
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrQueryRequired = errors.New("query parameter is required")
+	ErrQueryUnknown  = errors.New("query parameter is not allowed")
+	ErrQueryCount    = errors.New("query parameter repeated an invalid number of times")
+	ErrQueryValue    = errors.New("invalid query parameter value")
+)
+
+// queryPatterns caches the anchored form of each QueryParam.Pattern.
+var queryPatterns sync.Map // *regexp.Regexp -> *regexp.Regexp
+
+type QueryParam struct {
+	Required bool
+	// MinCount and MaxCount bound how often a present key may occur. A
+	// zero MaxCount allows a single occurrence; a negative one any number.
+	MinCount int
+	MaxCount int
+	// Enum lists the accepted values. An empty list accepts any value.
+	Enum []string
+	// Pattern, if set, must match every value in full, as if it were
+	// written as ^(?:pattern)$.
+	Pattern *regexp.Regexp
+	// Validators must all accept every value, e.g. NumericIsInt.
+	Validators []func(string) bool
+}
+
+// QuerySchema describes the query parameters accepted by an endpoint.
+type QuerySchema struct {
+	Params map[string]QueryParam
+	// AllowUnknown accepts keys that are not listed in Params.
+	AllowUnknown bool
+}
+
+type QueryError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *QueryError) Error() string {
+	if e.Value != "" {
+		return fmt.Sprintf("%s=%q: %v", e.Param, e.Value, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Param, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// QueryErrors collects the errors of all invalid parameters.
+type QueryErrors []*QueryError
+
+func (e QueryErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e QueryErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Param returns the errors reported for the parameter name.
+func (e QueryErrors) Param(name string) []*QueryError {
+	var errs []*QueryError
+	for _, err := range e {
+		if err.Param == name {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Validate checks values against the schema. It returns nil or a
+// QueryErrors with one entry per violation, ordered by parameter name.
+func (s *QuerySchema) Validate(values url.Values) error {
+	var errs QueryErrors
+
+	keys := make([]string, 0, len(values)+len(s.Params))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	for k := range s.Params {
+		if _, ok := values[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p, ok := s.Params[k]
+		if !ok {
+			if !s.AllowUnknown {
+				errs = append(errs, &QueryError{Param: k, Err: ErrQueryUnknown})
+			}
+			continue
+		}
+		errs = append(errs, p.validate(k, values[k])...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateQuery parses rawQuery and validates it against the schema.
+func (s *QuerySchema) ValidateQuery(rawQuery string) error {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrURLInvalid, err)
+	}
+	return s.Validate(values)
+}
+
+func (p QueryParam) validate(name string, values []string) []*QueryError {
+	if len(values) == 0 {
+		if p.Required {
+			return []*QueryError{{Param: name, Err: ErrQueryRequired}}
+		}
+		return nil
+	}
+
+	var errs []*QueryError
+	maxCount := p.MaxCount
+	if maxCount == 0 {
+		maxCount = 1
+	}
+	if len(values) < p.MinCount || maxCount > 0 && len(values) > maxCount {
+		errs = append(errs, &QueryError{
+			Param: name,
+			Err:   fmt.Errorf("%w: got %d", ErrQueryCount, len(values)),
+		})
+	}
+
+	for _, v := range values {
+		if err := p.validateValue(v); err != nil {
+			errs = append(errs, &QueryError{Param: name, Value: v, Err: err})
+		}
+	}
+	return errs
+}
+
+func (p QueryParam) validateValue(v string) error {
+	if len(p.Enum) > 0 && !containsString(p.Enum, v) {
+		return fmt.Errorf("%w: not one of %s", ErrQueryValue, strings.Join(p.Enum, ", "))
+	}
+	if p.Pattern != nil && !queryAnchoredPattern(p.Pattern).MatchString(v) {
+		return fmt.Errorf("%w: does not match %s", ErrQueryValue, p.Pattern)
+	}
+	for _, validator := range p.Validators {
+		if !validator(v) {
+			return ErrQueryValue
+		}
+	}
+	return nil
+}
+
+// queryAnchoredPattern returns re anchored at both ends. Checking the
+// position of an unanchored match is not enough, because leftmost-first
+// matching of "a|ab" finds only "a" in "ab".
+func queryAnchoredPattern(re *regexp.Regexp) *regexp.Regexp {
+	if anchored, ok := queryPatterns.Load(re); ok {
+		return anchored.(*regexp.Regexp)
+	}
+	anchored, _ := queryPatterns.LoadOrStore(re, regexp.MustCompile(`^(?:`+re.String()+`)$`))
+	return anchored.(*regexp.Regexp)
+}
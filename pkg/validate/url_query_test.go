@@ -0,0 +1,193 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestQuerySchemaValidate(t *testing.T) {
+	t.Parallel()
+
+	schema := validate.QuerySchema{
+		Params: map[string]validate.QueryParam{
+			"page":   {Validators: []func(string) bool{validate.NumericIsInt}},
+			"sort":   {Enum: []string{"asc", "desc"}},
+			"q":      {Required: true, Pattern: regexp.MustCompile(`^\w+$`)},
+			"tag":    {MaxCount: 3},
+			"filter": {MinCount: 2, MaxCount: -1},
+			"id":     {Pattern: regexp.MustCompile(`\d+`)},
+			"size":   {Pattern: regexp.MustCompile(`s|sm`)},
+		},
+	}
+
+	type in struct {
+		query string
+	}
+
+	type want struct {
+		err    error
+		params []string
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid query",
+			in: in{
+				query: "q=shoes&page=2&sort=asc&tag=a&tag=b",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "missing required parameter",
+			in: in{
+				query: "page=2",
+			},
+			want: want{
+				err:    validate.ErrQueryRequired,
+				params: []string{"q"},
+			},
+		},
+		{
+			name: "unknown parameter",
+			in: in{
+				query: "q=shoes&debug=1",
+			},
+			want: want{
+				err:    validate.ErrQueryUnknown,
+				params: []string{"debug"},
+			},
+		},
+		{
+			name: "repeated parameter",
+			in: in{
+				query: "q=shoes&page=1&page=2",
+			},
+			want: want{
+				err:    validate.ErrQueryCount,
+				params: []string{"page"},
+			},
+		},
+		{
+			name: "too many repetitions",
+			in: in{
+				query: "q=shoes&tag=a&tag=b&tag=c&tag=d",
+			},
+			want: want{
+				err:    validate.ErrQueryCount,
+				params: []string{"tag"},
+			},
+		},
+		{
+			name: "too few repetitions",
+			in: in{
+				query: "q=shoes&filter=a",
+			},
+			want: want{
+				err:    validate.ErrQueryCount,
+				params: []string{"filter"},
+			},
+		},
+		{
+			name: "unanchored pattern matches in full",
+			in: in{
+				query: "q=shoes&id=42&size=sm",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "unanchored pattern matching a substring",
+			in: in{
+				query: "q=shoes&id=abc1",
+			},
+			want: want{
+				err:    validate.ErrQueryValue,
+				params: []string{"id"},
+			},
+		},
+		{
+			name: "invalid values",
+			in: in{
+				query: "q=red+shoes&page=two&sort=up",
+			},
+			want: want{
+				err:    validate.ErrQueryValue,
+				params: []string{"page", "q", "sort"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.in.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = schema.Validate(values)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("Validate(%q) error = %v, want %v", tt.in.query, err, tt.want.err)
+			}
+			if err == nil {
+				return
+			}
+
+			var errs validate.QueryErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate(%q) error = %T, want QueryErrors", tt.in.query, err)
+			}
+			if len(errs) != len(tt.want.params) {
+				t.Fatalf("Validate(%q) = %v, want errors for %v", tt.in.query, errs, tt.want.params)
+			}
+			for i, p := range tt.want.params {
+				if errs[i].Param != p {
+					t.Errorf("Validate(%q) error %d for %q, want %q", tt.in.query, i, errs[i].Param, p)
+				}
+			}
+		})
+	}
+}
+
+func TestQuerySchemaValidateQuery(t *testing.T) {
+	t.Parallel()
+
+	schema := validate.QuerySchema{AllowUnknown: true}
+	if err := schema.ValidateQuery("a=1&b=2"); err != nil {
+		t.Errorf("ValidateQuery() error = %v, want nil", err)
+	}
+	if err := schema.ValidateQuery("a=%zz"); !errors.Is(err, validate.ErrURLInvalid) {
+		t.Errorf("ValidateQuery() error = %v, want %v", err, validate.ErrURLInvalid)
+	}
+}
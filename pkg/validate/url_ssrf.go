@@ -0,0 +1,163 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+var (
+	ErrURLForbiddenAddress = errors.New("URL host resolves to a forbidden address")
+	ErrURLResolve          = errors.New("URL host could not be resolved")
+)
+
+// urlSSRFMetadataAddrs are cloud metadata endpoints. They are blocked even
+// if an allowed prefix covers them.
+var urlSSRFMetadataAddrs = []netip.Addr{
+	netip.MustParseAddr("169.254.169.254"), // AWS, GCP, Azure, OpenStack
+	netip.MustParseAddr("169.254.170.2"),   // AWS ECS task metadata
+	netip.MustParseAddr("100.100.100.200"), // Alibaba Cloud
+	netip.MustParseAddr("192.0.0.192"),     // Oracle Cloud
+	netip.MustParseAddr("fd00:ec2::254"),   // AWS IPv6
+}
+
+var defaultURLSSRFGuard = NewURLSSRFGuard(net.DefaultResolver)
+
+// URLSSRFGuard rejects URLs whose host resolves to an address that must not
+// be reachable from user-supplied URLs, such as loopback, private networks
+// or cloud metadata services.
+type URLSSRFGuard struct {
+	Resolver Resolver
+	// Options are checked before the host is resolved.
+	Options URLOptions
+	// Allow exempts prefixes from the blocklist. Metadata service
+	// addresses stay blocked.
+	Allow []netip.Prefix
+	// Deny blocks prefixes in addition to the built-in blocklist.
+	Deny []netip.Prefix
+}
+
+func NewURLSSRFGuard(resolver Resolver) *URLSSRFGuard {
+	return &URLSSRFGuard{
+		Resolver: resolver,
+		Options: URLOptions{
+			AllowedSchemes: []string{"http", "https"},
+			RequireHost:    true,
+			ForbidUserinfo: true,
+			Reference:      URLAbsolute,
+		},
+	}
+}
+
+// URLValidateSSRF checks urlStr with the default guard and the system
+// resolver.
+func URLValidateSSRF(ctx context.Context, urlStr string) error {
+	return defaultURLSSRFGuard.Validate(ctx, urlStr)
+}
+
+// URLDialerControl is a net.Dialer Control function that rejects
+// connections to forbidden addresses using the default guard.
+func URLDialerControl(network string, address string, c syscall.RawConn) error {
+	return defaultURLSSRFGuard.Control(network, address, c)
+}
+
+func (g *URLSSRFGuard) Validate(ctx context.Context, urlStr string) error {
+	if !URLIsValid(urlStr) {
+		return ErrURLInvalid
+	}
+	if err := URLValidate(urlStr, g.Options); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrURLInvalid, err)
+	}
+
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return g.checkAddr(ip)
+	}
+
+	addrs, err := g.Resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrURLResolve, err)
+	}
+	if len(addrs) == 0 {
+		return ErrURLResolve
+	}
+	// Every address must be allowed since the client may connect to any.
+	for _, a := range addrs {
+		ip, ok := netip.AddrFromSlice(a.IP)
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrURLResolve, a.IP)
+		}
+		// net.IP stores IPv4 addresses in their 16-byte mapped form.
+		if err := g.checkAddr(ip.Unmap()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Control re-checks the address actually being dialed. Used as
+// net.Dialer.Control it defeats DNS rebinding, where the host resolves to
+// a public address during validation and to an internal one at connect
+// time.
+func (g *URLSSRFGuard) Control(_ string, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrURLForbiddenAddress, address)
+	}
+	return g.checkAddr(ap.Addr())
+}
+
+// AddrIsAllowed reports whether ip may be connected to.
+func (g *URLSSRFGuard) AddrIsAllowed(ip netip.Addr) bool {
+	ip = ip.WithZone("")
+	for _, m := range urlSSRFMetadataAddrs {
+		if ip == m || ip.Is4In6() && ip.Unmap() == m {
+			return false
+		}
+	}
+	if ipInPrefixes(ip, g.Allow) {
+		return true
+	}
+	if ipInPrefixes(ip, ipSpecialPurposePrefixes) || ipInPrefixes(ip, g.Deny) {
+		return false
+	}
+	return ip.IsValid()
+}
+
+func (g *URLSSRFGuard) checkAddr(ip netip.Addr) error {
+	if !g.AddrIsAllowed(ip) {
+		return fmt.Errorf("%w: %v", ErrURLForbiddenAddress, ip)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestURLSSRFGuardValidate(t *testing.T) {
+	t.Parallel()
+
+	resolver := &stubResolver{
+		ips: map[string][]net.IPAddr{
+			"example.com":  {{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("2606:2800:220:1:248:1893:25c8:1946")}},
+			"internal.dev": {{IP: net.ParseIP("10.1.2.3")}},
+			"mixed.dev":    {{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("127.0.0.1")}},
+		},
+	}
+
+	type in struct {
+		url string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "public host",
+			in: in{
+				url: "https://example.com/hook",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "private host",
+			in: in{
+				url: "https://internal.dev/hook",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "one of several addresses is loopback",
+			in: in{
+				url: "https://mixed.dev/hook",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "loopback literal",
+			in: in{
+				url: "http://127.0.0.1:8080/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "metadata service",
+			in: in{
+				url: "http://169.254.169.254/latest/meta-data/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "cgnat",
+			in: in{
+				url: "http://100.64.0.1/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "ipv4-mapped ipv6 literal",
+			in: in{
+				url: "http://[::ffff:93.184.216.34]/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "ipv6 link-local with zone",
+			in: in{
+				url: "http://[fe80::1%25eth0]/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "multicast",
+			in: in{
+				url: "http://[ff02::1]/",
+			},
+			want: want{
+				err: validate.ErrURLForbiddenAddress,
+			},
+		},
+		{
+			name: "unresolvable host",
+			in: in{
+				url: "https://unknown.dev/",
+			},
+			want: want{
+				err: validate.ErrURLResolve,
+			},
+		},
+		{
+			name: "scheme not allowed",
+			in: in{
+				url: "gopher://example.com/",
+			},
+			want: want{
+				err: validate.ErrURLScheme,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := validate.NewURLSSRFGuard(resolver)
+			err := g.Validate(context.Background(), tt.in.url)
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Validate(%q) error = %v, want %v", tt.in.url, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestURLSSRFGuardAllow(t *testing.T) {
+	t.Parallel()
+
+	g := validate.NewURLSSRFGuard(&stubResolver{})
+	g.Allow = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("169.254.0.0/16")}
+	g.Deny = []netip.Prefix{netip.MustParsePrefix("93.184.216.0/24")}
+
+	type in struct {
+		addr string
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "allowed private prefix",
+			in: in{
+				addr: "10.1.2.3",
+			},
+			want: want{
+				result: true,
+			},
+		},
+		{
+			name: "metadata address stays blocked",
+			in: in{
+				addr: "169.254.169.254",
+			},
+			want: want{
+				result: false,
+			},
+		},
+		{
+			name: "denied public prefix",
+			in: in{
+				addr: "93.184.216.34",
+			},
+			want: want{
+				result: false,
+			},
+		},
+		{
+			name: "public address",
+			in: in{
+				addr: "8.8.8.8",
+			},
+			want: want{
+				result: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := g.AddrIsAllowed(netip.MustParseAddr(tt.in.addr))
+			if got != tt.want.result {
+				t.Errorf("AddrIsAllowed(%s) = %v, want %v", tt.in.addr, got, tt.want.result)
+			}
+		})
+	}
+}
+
+func TestURLSSRFGuardControl(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on tcp: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+
+	// A rebinding host passes validation with a public address but the
+	// connection goes to loopback.
+	g := validate.NewURLSSRFGuard(&stubResolver{})
+	d := &net.Dialer{Timeout: time.Second, Control: g.Control}
+	if _, err := d.Dial("tcp", ln.Addr().String()); !errors.Is(err, validate.ErrURLForbiddenAddress) {
+		t.Errorf("Dial(%s) error = %v, want %v", ln.Addr(), err, validate.ErrURLForbiddenAddress)
+	}
+
+	g.Allow = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	c, err := d.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial(%s) with allowed loopback error = %v", ln.Addr(), err)
+	}
+	c.Close()
+}
@@ -0,0 +1,303 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	uriTemplateMaxPrefix = 9999
+	// uriTemplateMaxRepeat is the largest repeat count accepted by
+	// regexp. Longer prefixes are checked after matching.
+	uriTemplateMaxRepeat = 1000
+)
+
+var (
+	ErrURITemplate         = errors.New("invalid URI template")
+	ErrURITemplateMismatch = errors.New("URL does not match URI template")
+)
+
+const (
+	// uriTemplatePct avoids a nested repeat count, which regexp
+	// multiplies with the prefix length when checking its limit.
+	uriTemplatePct        = `%[0-9A-Fa-f][0-9A-Fa-f]`
+	uriTemplateUnreserved = `A-Za-z0-9\-._~`
+	uriTemplateReserved   = `:/?#\[\]@!$&'()*+,;=`
+)
+
+// uriTemplateOperator describes the expansion of an RFC 6570 operator,
+// see appendix A of the RFC.
+type uriTemplateOperator struct {
+	first   string
+	sep     string
+	named   bool
+	ifEmpty string
+	// chars are the characters a value may contain unencoded, minus
+	// the separator so that consecutive values can be told apart.
+	chars string
+	// list are the characters that may join the items of a non-exploded
+	// list or associative array, but are matched lazily so that they
+	// separate variables where possible.
+	list string
+}
+
+var uriTemplateOperators = map[byte]uriTemplateOperator{
+	0:   {first: "", sep: ",", chars: uriTemplateUnreserved, list: ","},
+	'+': {first: "", sep: ",", chars: uriTemplateUnreserved + uriTemplateReserved},
+	'#': {first: "#", sep: ",", chars: uriTemplateUnreserved + uriTemplateReserved},
+	'.': {first: ".", sep: ".", chars: `A-Za-z0-9\-_~`, list: `,.`},
+	'/': {first: "/", sep: "/", chars: uriTemplateUnreserved, list: ","},
+	';': {first: ";", sep: ";", named: true, chars: uriTemplateUnreserved, list: ","},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "=", chars: uriTemplateUnreserved, list: ","},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "=", chars: uriTemplateUnreserved, list: ","},
+}
+
+type uriTemplateVar struct {
+	name    string
+	prefix  int
+	explode bool
+}
+
+// URITemplate is a parsed RFC 6570 URI Template (levels 1 to 4).
+type URITemplate struct {
+	raw      string
+	vars     []string
+	prefixes []int
+	re       *regexp.Regexp
+}
+
+func ParseURITemplate(s string) (*URITemplate, error) {
+	t := &URITemplate{raw: s}
+	var pattern strings.Builder
+	pattern.WriteByte('^')
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed expression at offset %d", ErrURITemplate, i)
+			}
+			expr, err := t.parseExpression(s[i+1:i+end], i)
+			if err != nil {
+				return nil, err
+			}
+			pattern.WriteString(expr)
+			i += end + 1
+		case c == '}':
+			return nil, fmt.Errorf("%w: unexpected '}' at offset %d", ErrURITemplate, i)
+		case c == '%':
+			if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return nil, fmt.Errorf("%w: invalid percent-encoding at offset %d", ErrURITemplate, i)
+			}
+			pattern.WriteString(regexp.QuoteMeta(s[i : i+3]))
+			i += 3
+		case c <= ' ' || strings.IndexByte(`"'<>\^`+"`|", c) >= 0:
+			return nil, fmt.Errorf("%w: invalid literal %q at offset %d", ErrURITemplate, c, i)
+		default:
+			pattern.WriteString(regexp.QuoteMeta(s[i : i+1]))
+			i++
+		}
+	}
+
+	pattern.WriteByte('$')
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrURITemplate, err)
+	}
+	t.re = re
+	return t, nil
+}
+
+func URITemplateIsValid(s string) bool {
+	_, err := ParseURITemplate(s)
+	return err == nil
+}
+
+func (t *URITemplate) String() string {
+	return t.raw
+}
+
+// Variables returns the variable names in order of appearance.
+func (t *URITemplate) Variables() []string {
+	return append([]string(nil), t.vars...)
+}
+
+// Match reports whether u is a possible expansion of the template and
+// returns the decoded values of the variables it defines. Exploded
+// variables are returned with their separators.
+func (t *URITemplate) Match(u string) (map[string]string, bool) {
+	m := t.re.FindStringSubmatchIndex(u)
+	if m == nil {
+		return nil, false
+	}
+
+	values := make(map[string]string)
+	for i, name := range t.vars {
+		start, end := m[2*i+2], m[2*i+3]
+		if start < 0 {
+			continue
+		}
+		v := u[start:end]
+		if unescaped, err := url.PathUnescape(v); err == nil {
+			v = unescaped
+		}
+		if prefix := t.prefixes[i]; prefix > 0 && utf8.RuneCountInString(v) > prefix {
+			return nil, false
+		}
+		values[name] = v
+	}
+	return values, true
+}
+
+// Validate returns ErrURITemplateMismatch if u is not an expansion of the
+// template.
+func (t *URITemplate) Validate(u string) error {
+	if _, ok := t.Match(u); !ok {
+		return fmt.Errorf("%w: %q does not match %q", ErrURITemplateMismatch, u, t.raw)
+	}
+	return nil
+}
+
+func (t *URITemplate) parseExpression(expr string, offset int) (string, error) {
+	if expr == "" {
+		return "", fmt.Errorf("%w: empty expression at offset %d", ErrURITemplate, offset)
+	}
+
+	var opChar byte
+	if strings.IndexByte("+#./;?&", expr[0]) >= 0 {
+		opChar, expr = expr[0], expr[1:]
+	} else if strings.IndexByte("=,!@|", expr[0]) >= 0 {
+		return "", fmt.Errorf("%w: reserved operator %q at offset %d", ErrURITemplate, expr[0], offset)
+	}
+	op := uriTemplateOperators[opChar]
+
+	var parts []string
+	for _, spec := range strings.Split(expr, ",") {
+		v, err := parseURITemplateVar(spec, offset)
+		if err != nil {
+			return "", err
+		}
+		t.vars = append(t.vars, v.name)
+		t.prefixes = append(t.prefixes, v.prefix)
+		parts = append(parts, op.pattern(v, len(t.vars)))
+	}
+
+	// Undefined variables are omitted from the expansion, so each one is
+	// optional and the operator prefix only appears before the first
+	// defined variable.
+	var b strings.Builder
+	b.WriteString("(?:")
+	b.WriteString(regexp.QuoteMeta(op.first))
+	for i, p := range parts {
+		b.WriteString("(?:")
+		if i > 0 {
+			b.WriteString("(?:" + regexp.QuoteMeta(op.sep) + ")?")
+		}
+		b.WriteString(p)
+		b.WriteString(")?")
+	}
+	b.WriteString(")?")
+	return b.String(), nil
+}
+
+func (op uriTemplateOperator) pattern(v uriTemplateVar, group int) string {
+	item := fmt.Sprintf(`(?:[%s]|%s)`, op.chars, uriTemplatePct)
+	var value string
+	switch {
+	case v.prefix > uriTemplateMaxRepeat:
+		value = item + "*"
+	case v.prefix > 0:
+		value = item + fmt.Sprintf("{0,%d}", v.prefix)
+	case op.list != "":
+		// A list or associative array joins its items with commas.
+		value = fmt.Sprintf(`%s*(?:[%s]%s*)*?`, item, regexp.QuoteMeta(op.list), item)
+	default:
+		value = item + "*"
+	}
+	name := regexp.QuoteMeta(v.name)
+	sep := regexp.QuoteMeta(op.sep)
+
+	switch {
+	case v.explode && op.named:
+		// Exploded named values repeat the name, or use their own keys
+		// for associative arrays, so the whole expansion is captured.
+		pair := fmt.Sprintf(`(?:[%s]|%s)+(?:=%s)?`, uriTemplateUnreserved, uriTemplatePct, value)
+		return fmt.Sprintf("(?P<v%d>%s(?:%s%s)*)", group, pair, sep, pair)
+	case v.explode:
+		item := fmt.Sprintf(`(?:[%s=]|%s)*`, op.chars, uriTemplatePct)
+		return fmt.Sprintf("(?P<v%d>%s(?:%s%s)*)", group, item, sep, item)
+	case op.named && op.ifEmpty != "":
+		return fmt.Sprintf("%s=(?P<v%d>%s)", name, group, value)
+	case op.named:
+		return fmt.Sprintf("%s(?:=(?P<v%d>%s))?", name, group, value)
+	}
+	return fmt.Sprintf("(?P<v%d>%s)", group, value)
+}
+
+func parseURITemplateVar(spec string, offset int) (uriTemplateVar, error) {
+	var v uriTemplateVar
+	switch {
+	case strings.HasSuffix(spec, "*"):
+		v.explode = true
+		spec = spec[:len(spec)-1]
+	case strings.Contains(spec, ":"):
+		i := strings.IndexByte(spec, ':')
+		n, err := strconv.Atoi(spec[i+1:])
+		if err != nil || n < 1 || n > uriTemplateMaxPrefix || spec[i+1] == '0' {
+			return v, fmt.Errorf("%w: invalid prefix modifier in %q at offset %d", ErrURITemplate, spec, offset)
+		}
+		v.prefix = n
+		spec = spec[:i]
+	}
+
+	if !uriTemplateIsVarname(spec) {
+		return v, fmt.Errorf("%w: invalid variable name %q at offset %d", ErrURITemplate, spec, offset)
+	}
+	v.name = spec
+	return v, nil
+}
+
+func uriTemplateIsVarname(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '.':
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
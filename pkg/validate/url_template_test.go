@@ -0,0 +1,386 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestParseURITemplate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		template string
+	}
+
+	type want struct {
+		vars []string
+		err  error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "all operators",
+			in: in{
+				template: "{+base}/users{/id}{.format}{;v}{?q,lang}{&page}{#section}{x,y}",
+			},
+			want: want{
+				vars: []string{"base", "id", "format", "v", "q", "lang", "page", "section", "x", "y"},
+				err:  nil,
+			},
+		},
+		{
+			name: "modifiers",
+			in: in{
+				template: "/files{/path*}{?fields:3}",
+			},
+			want: want{
+				vars: []string{"path", "fields"},
+				err:  nil,
+			},
+		},
+		{
+			name: "unclosed expression",
+			in: in{
+				template: "/users/{id",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+		{
+			name: "stray closing brace",
+			in: in{
+				template: "/users/id}",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+		{
+			name: "reserved operator",
+			in: in{
+				template: "/users/{!id}",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+		{
+			name: "invalid prefix",
+			in: in{
+				template: "/users/{id:10000}",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+		{
+			name: "invalid variable name",
+			in: in{
+				template: "/users/{user id}",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+		{
+			name: "empty expression",
+			in: in{
+				template: "/users/{}",
+			},
+			want: want{
+				err: validate.ErrURITemplate,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.ParseURITemplate(tt.in.template)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("ParseURITemplate(%q) error = %v, want %v", tt.in.template, err, tt.want.err)
+			}
+			if err == nil && !reflect.DeepEqual(got.Variables(), tt.want.vars) {
+				t.Errorf("Variables() = %v, want %v", got.Variables(), tt.want.vars)
+			}
+		})
+	}
+}
+
+func TestURITemplateMatch(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		template string
+		url      string
+	}
+
+	type want struct {
+		values map[string]string
+		ok     bool
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "path and query",
+			in: in{
+				template: "/users/{id}{?fields,limit}",
+				url:      "/users/42?fields=name&limit=10",
+			},
+			want: want{
+				values: map[string]string{"id": "42", "fields": "name", "limit": "10"},
+				ok:     true,
+			},
+		},
+		{
+			name: "optional query omitted",
+			in: in{
+				template: "/users/{id}{?fields,limit}",
+				url:      "/users/42",
+			},
+			want: want{
+				values: map[string]string{"id": "42"},
+				ok:     true,
+			},
+		},
+		{
+			name: "extra path segment",
+			in: in{
+				template: "/users/{id}{?fields,limit}",
+				url:      "/users/42/posts",
+			},
+			want: want{
+				ok: false,
+			},
+		},
+		{
+			name: "reserved expansion",
+			in: in{
+				template: "{+base}/items{/id}",
+				url:      "https://api.example.com/v1/items/7",
+			},
+			want: want{
+				values: map[string]string{"base": "https://api.example.com/v1", "id": "7"},
+				ok:     true,
+			},
+		},
+		{
+			name: "percent-encoded value",
+			in: in{
+				template: "/search{?q}",
+				url:      "/search?q=red%20shoes",
+			},
+			want: want{
+				values: map[string]string{"q": "red shoes"},
+				ok:     true,
+			},
+		},
+		{
+			name: "label and path parameters",
+			in: in{
+				template: "/report{.format}{;x,y}",
+				url:      "/report.json;x=1;y=2",
+			},
+			want: want{
+				values: map[string]string{"format": "json", "x": "1", "y": "2"},
+				ok:     true,
+			},
+		},
+		{
+			name: "exploded path",
+			in: in{
+				template: "/files{/path*}",
+				url:      "/files/a/b/c",
+			},
+			want: want{
+				values: map[string]string{"path": "a/b/c"},
+				ok:     true,
+			},
+		},
+		{
+			name: "prefix exceeded",
+			in: in{
+				template: "/color/{hex:6}",
+				url:      "/color/ff00ff00",
+			},
+			want: want{
+				ok: false,
+			},
+		},
+		{
+			name: "reserved character in simple expansion",
+			in: in{
+				template: "/users/{id}",
+				url:      "/users/a/b",
+			},
+			want: want{
+				ok: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := validate.ParseURITemplate(tt.in.template)
+			if err != nil {
+				t.Fatalf("ParseURITemplate(%q) error = %v", tt.in.template, err)
+			}
+			got, ok := tmpl.Match(tt.in.url)
+			if ok != tt.want.ok {
+				t.Fatalf("Match(%q) ok = %v, want %v", tt.in.url, ok, tt.want.ok)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want.values) {
+				t.Errorf("Match(%q) = %v, want %v", tt.in.url, got, tt.want.values)
+			}
+			if err := tmpl.Validate(tt.in.url); (err == nil) != tt.want.ok {
+				t.Errorf("Validate(%q) error = %v, want ok %v", tt.in.url, err, tt.want.ok)
+			}
+		})
+	}
+}
+
+// TestURITemplateRFC6570 matches the level 4 examples of RFC 6570,
+// section 3.2, against their templates.
+func TestURITemplateRFC6570(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		template string
+		url      string
+	}{
+		{template: "{var:3}", url: "val"},
+		{template: "{var:30}", url: "value"},
+		{template: "{list}", url: "red,green,blue"},
+		{template: "{list*}", url: "red,green,blue"},
+		{template: "{keys}", url: "semi,%3B,dot,.,comma,%2C"},
+		{template: "{keys*}", url: "semi=%3B,dot=.,comma=%2C"},
+		{template: "{+path:6}/here", url: "/foo/b/here"},
+		{template: "{+list}", url: "red,green,blue"},
+		{template: "{+list*}", url: "red,green,blue"},
+		{template: "{+keys}", url: "semi,;,dot,.,comma,,"},
+		{template: "{+keys*}", url: "semi=;,dot=.,comma=,"},
+		{template: "{#path:6}/here", url: "#/foo/b/here"},
+		{template: "{#list}", url: "#red,green,blue"},
+		{template: "{#list*}", url: "#red,green,blue"},
+		{template: "{#keys}", url: "#semi,;,dot,.,comma,,"},
+		{template: "{#keys*}", url: "#semi=;,dot=.,comma=,"},
+		{template: "X{.var:3}", url: "X.val"},
+		{template: "X{.list}", url: "X.red,green,blue"},
+		{template: "X{.list*}", url: "X.red.green.blue"},
+		{template: "X{.keys}", url: "X.semi,%3B,dot,.,comma,%2C"},
+		{template: "X{.keys*}", url: "X.semi=%3B.dot=..comma=%2C"},
+		{template: "{/var:1,var}", url: "/v/value"},
+		{template: "{/list}", url: "/red,green,blue"},
+		{template: "{/list*}", url: "/red/green/blue"},
+		{template: "{/list*,path:4}", url: "/red/green/blue/%2Ffoo"},
+		{template: "{/keys}", url: "/semi,%3B,dot,.,comma,%2C"},
+		{template: "{/keys*}", url: "/semi=%3B/dot=./comma=%2C"},
+		{template: "{;hello:5}", url: ";hello=Hello"},
+		{template: "{;list}", url: ";list=red,green,blue"},
+		{template: "{;list*}", url: ";list=red;list=green;list=blue"},
+		{template: "{;keys}", url: ";keys=semi,%3B,dot,.,comma,%2C"},
+		{template: "{;keys*}", url: ";semi=%3B;dot=.;comma=%2C"},
+		{template: "{?var:3}", url: "?var=val"},
+		{template: "{?list}", url: "?list=red,green,blue"},
+		{template: "{?list*}", url: "?list=red&list=green&list=blue"},
+		{template: "{?keys}", url: "?keys=semi,%3B,dot,.,comma,%2C"},
+		{template: "{?keys*}", url: "?semi=%3B&dot=.&comma=%2C"},
+		{template: "{&var:3}", url: "&var=val"},
+		{template: "{&list}", url: "&list=red,green,blue"},
+		{template: "{&list*}", url: "&list=red&list=green&list=blue"},
+		{template: "{&keys}", url: "&keys=semi,%3B,dot,.,comma,%2C"},
+		{template: "{&keys*}", url: "&semi=%3B&dot=.&comma=%2C"},
+	}
+
+	for _, tt := range tests {
+		tmpl, err := validate.ParseURITemplate(tt.template)
+		if err != nil {
+			t.Errorf("ParseURITemplate(%q) error = %v", tt.template, err)
+			continue
+		}
+		if err := tmpl.Validate(tt.url); err != nil {
+			t.Errorf("Validate(%q) error = %v", tt.url, err)
+		}
+	}
+}
+
+func TestURITemplateListSeparator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		template string
+		url      string
+		want     map[string]string
+	}{
+		{template: "{x,y}", url: "1024,768", want: map[string]string{"x": "1024", "y": "768"}},
+		{template: "{x}", url: "1024,768", want: map[string]string{"x": "1024,768"}},
+		{template: "{?list,page}", url: "?list=red,green&page=2", want: map[string]string{"list": "red,green", "page": "2"}},
+		{template: "{.x,y}", url: ".a.b", want: map[string]string{"x": "a", "y": "b"}},
+		{template: "/color/{hex:6}", url: "/color/ff,00ff"},
+	}
+
+	for _, tt := range tests {
+		tmpl, err := validate.ParseURITemplate(tt.template)
+		if err != nil {
+			t.Fatalf("ParseURITemplate(%q) error = %v", tt.template, err)
+		}
+		got, ok := tmpl.Match(tt.url)
+		if ok != (tt.want != nil) || ok && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Match(%q) = %v, %v, want %v", tt.template, tt.url, got, ok, tt.want)
+		}
+	}
+}
+
+func TestURITemplateLongPrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range []int{1000, 1001, 5000, 9999} {
+		template := fmt.Sprintf("/blob/{data:%d}", prefix)
+		tmpl, err := validate.ParseURITemplate(template)
+		if err != nil {
+			t.Fatalf("ParseURITemplate(%q) error = %v", template, err)
+		}
+		fits := "/blob/" + strings.Repeat("a", prefix)
+		if err := tmpl.Validate(fits); err != nil {
+			t.Errorf("%s: Validate(%d characters) error = %v", template, prefix, err)
+		}
+		if err := tmpl.Validate(fits + "a"); err == nil {
+			t.Errorf("%s: Validate(%d characters) error = nil", template, prefix+1)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UUIDVariant int
+
+const (
+	UUIDVariantNCS UUIDVariant = iota
+	// UUIDVariantRFC9562 is the variant of versions 1 to 8, formerly
+	// known as the RFC 4122 variant.
+	UUIDVariantRFC9562
+	UUIDVariantMicrosoft
+	UUIDVariantFuture
+)
+
+// uuidGregorianOffset is the number of 100 ns intervals between the start
+// of the Gregorian calendar, used by versions 1 and 6, and the Unix epoch.
+const uuidGregorianOffset = 122192928000000000
+
+var (
+	ErrUUIDInvalid = errors.New("invalid UUID")
+	ErrUUIDVersion = fmt.Errorf("%w: version not allowed", ErrUUIDInvalid)
+	ErrUUIDVariant = fmt.Errorf("%w: unsupported variant", ErrUUIDInvalid)
+)
+
+// UUID is a parsed UUID. The nil and max UUIDs have version 0.
+type UUID struct {
+	Bytes   [16]byte
+	Version int
+	Variant UUIDVariant
+}
+
+func init() {
+	RegisterRule("uuid", func(param string) (ValidationRule, error) {
+		if param == "" {
+			return StringRule(UUIDIsValid, ErrUUIDInvalid), nil
+		}
+		var versions []int
+		for _, p := range strings.Split(param, "|") {
+			v, err := strconv.Atoi(p)
+			if err != nil || v < 1 || v > 8 {
+				return nil, fmt.Errorf("%w: %q", ErrUUIDVersion, p)
+			}
+			versions = append(versions, v)
+		}
+		return StringRule(func(s string) bool { return UUIDIsVersion(s, versions...) }, ErrUUIDVersion), nil
+	})
+}
+
+// UUIDParse parses a UUID in its canonical hyphenated form, e.g.
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", in either case. Apart from the
+// nil and max UUIDs, only the RFC 9562 variant with versions 1 to 8 is
+// accepted.
+func UUIDParse(s string) (*UUID, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return nil, fmt.Errorf("%w: %q is not in 8-4-4-4-12 form", ErrUUIDInvalid, s)
+	}
+
+	u := &UUID{}
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u.Bytes[:], []byte(digits)); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUUIDInvalid, s)
+	}
+	if u.IsNil() || u.IsMax() {
+		u.Variant = UUIDVariantFuture
+		if u.IsNil() {
+			u.Variant = UUIDVariantNCS
+		}
+		return u, nil
+	}
+
+	switch v := u.Bytes[8]; {
+	case v&0x80 == 0:
+		u.Variant = UUIDVariantNCS
+	case v&0xc0 == 0x80:
+		u.Variant = UUIDVariantRFC9562
+	case v&0xe0 == 0xc0:
+		u.Variant = UUIDVariantMicrosoft
+	default:
+		u.Variant = UUIDVariantFuture
+	}
+	if u.Variant != UUIDVariantRFC9562 {
+		return nil, fmt.Errorf("%w: %q", ErrUUIDVariant, s)
+	}
+
+	u.Version = int(u.Bytes[6] >> 4)
+	if u.Version < 1 || u.Version > 8 {
+		return nil, fmt.Errorf("%w: version %d", ErrUUIDInvalid, u.Version)
+	}
+	return u, nil
+}
+
+func UUIDIsValid(s string) bool {
+	_, err := UUIDParse(s)
+	return err == nil
+}
+
+// UUIDIsVersion checks if s is a UUID of one of versions.
+func UUIDIsVersion(s string, versions ...int) bool {
+	u, err := UUIDParse(s)
+	if err != nil {
+		return false
+	}
+	for _, v := range versions {
+		if u.Version == v && v != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func UUIDIsNil(s string) bool {
+	u, err := UUIDParse(s)
+	return err == nil && u.IsNil()
+}
+
+func UUIDIsMax(s string) bool {
+	u, err := UUIDParse(s)
+	return err == nil && u.IsMax()
+}
+
+func (u *UUID) IsNil() bool {
+	return u.Bytes == [16]byte{}
+}
+
+func (u *UUID) IsMax() bool {
+	return u.Bytes == [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+}
+
+// Time returns the timestamp of version 1, 6 and 7 UUIDs.
+func (u *UUID) Time() (time.Time, bool) {
+	b := u.Bytes
+	var ticks uint64
+	switch u.Version {
+	case 1:
+		ticks = uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)<<48 |
+			uint64(binary.BigEndian.Uint16(b[4:6]))<<32 |
+			uint64(binary.BigEndian.Uint32(b[0:4]))
+	case 6:
+		ticks = uint64(binary.BigEndian.Uint32(b[0:4]))<<28 |
+			uint64(binary.BigEndian.Uint16(b[4:6]))<<12 |
+			uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)
+	case 7:
+		ms := uint64(binary.BigEndian.Uint16(b[0:2]))<<32 | uint64(binary.BigEndian.Uint32(b[2:6]))
+		return time.UnixMilli(int64(ms)).UTC(), true
+	default:
+		return time.Time{}, false
+	}
+	ticks -= uuidGregorianOffset
+	t := int64(ticks)
+	return time.Unix(t/1e7, t%1e7*100).UTC(), true
+}
+
+func (u *UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u.Bytes[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u.Bytes[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u.Bytes[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u.Bytes[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u.Bytes[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,177 @@
+// MIT License
+//
+// Copyright (c) 2023 progxeno
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/progxeno/validate/pkg/validate"
+)
+
+func TestUUIDParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		version int
+		time    time.Time
+		err     error
+	}
+
+	// Test vectors from RFC 9562, appendix A and B.
+	vectorTime := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "version 1",
+			in:   in{s: "C232AB00-9414-11EC-B3C8-9F6BDECED846"},
+			want: want{version: 1, time: vectorTime},
+		},
+		{
+			name: "version 3",
+			in:   in{s: "5df41881-3aed-3515-88a7-2f4a814cf09e"},
+			want: want{version: 3},
+		},
+		{
+			name: "version 4",
+			in:   in{s: "919108f7-52d1-4320-9bac-f847db4148a8"},
+			want: want{version: 4},
+		},
+		{
+			name: "version 5",
+			in:   in{s: "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+			want: want{version: 5},
+		},
+		{
+			name: "version 6",
+			in:   in{s: "1EC9414C-232A-6B00-B3C8-9F6BDECED846"},
+			want: want{version: 6, time: vectorTime},
+		},
+		{
+			name: "version 7",
+			in:   in{s: "017F22E2-79B0-7CC3-98C4-DC0C0C07398F"},
+			want: want{version: 7, time: vectorTime},
+		},
+		{
+			name: "version 8",
+			in:   in{s: "2489E9AD-2EE2-8E00-8EC9-32D5F69181C0"},
+			want: want{version: 8},
+		},
+		{
+			name: "nil",
+			in:   in{s: "00000000-0000-0000-0000-000000000000"},
+			want: want{version: 0},
+		},
+		{
+			name: "max",
+			in:   in{s: "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF"},
+			want: want{version: 0},
+		},
+		{
+			name: "version 0",
+			in:   in{s: "919108f7-52d1-0320-9bac-f847db4148a8"},
+			want: want{err: validate.ErrUUIDInvalid},
+		},
+		{
+			name: "version 9",
+			in:   in{s: "919108f7-52d1-9320-9bac-f847db4148a8"},
+			want: want{err: validate.ErrUUIDInvalid},
+		},
+		{
+			name: "microsoft variant",
+			in:   in{s: "919108f7-52d1-4320-cbac-f847db4148a8"},
+			want: want{err: validate.ErrUUIDVariant},
+		},
+		{
+			name: "without hyphens",
+			in:   in{s: "919108f752d143209bacf847db4148a8"},
+			want: want{err: validate.ErrUUIDInvalid},
+		},
+		{
+			name: "braces",
+			in:   in{s: "{919108f7-52d1-4320-9bac-f847db4148a8}"},
+			want: want{err: validate.ErrUUIDInvalid},
+		},
+		{
+			name: "invalid hex",
+			in:   in{s: "919108g7-52d1-4320-9bac-f847db4148a8"},
+			want: want{err: validate.ErrUUIDInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validate.UUIDParse(tt.in.s)
+			if tt.want.err != nil {
+				if !errors.Is(err, tt.want.err) {
+					t.Errorf("UUIDParse(%q) error = %v, want %v", tt.in.s, err, tt.want.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UUIDParse(%q) error = %v", tt.in.s, err)
+			}
+			if got.Version != tt.want.version {
+				t.Errorf("UUIDParse(%q).Version = %d, want %d", tt.in.s, got.Version, tt.want.version)
+			}
+			if ts, ok := got.Time(); ok != !tt.want.time.IsZero() || !ts.Equal(tt.want.time) {
+				t.Errorf("UUIDParse(%q).Time() = %v, %v, want %v", tt.in.s, ts, ok, tt.want.time)
+			}
+		})
+	}
+}
+
+func TestUUIDIsVersion(t *testing.T) {
+	t.Parallel()
+
+	const v4 = "919108f7-52d1-4320-9bac-f847db4148a8"
+	if !validate.UUIDIsVersion(v4, 4, 7) || validate.UUIDIsVersion(v4, 7) {
+		t.Errorf("UUIDIsVersion(%q) does not match version 4", v4)
+	}
+	if validate.UUIDIsVersion("00000000-0000-0000-0000-000000000000", 0) {
+		t.Errorf("UUIDIsVersion() matched the nil UUID")
+	}
+	if !validate.UUIDIsNil("00000000-0000-0000-0000-000000000000") || !validate.UUIDIsMax("ffffffff-ffff-ffff-ffff-ffffffffffff") {
+		t.Errorf("UUIDIsNil() or UUIDIsMax() = false, want true")
+	}
+
+	v := validate.NewValidate()
+	if err := v.AddNamedRule("uuid=4|7"); err != nil {
+		t.Fatalf("AddNamedRule() error = %v", err)
+	}
+	if err := v.Validate("C232AB00-9414-11EC-B3C8-9F6BDECED846"); !errors.Is(err, validate.ErrUUIDVersion) {
+		t.Errorf("Validate(v1) = %v, want %v", err, validate.ErrUUIDVersion)
+	}
+	if err := v.Validate(v4); err != nil {
+		t.Errorf("Validate(v4) = %v, want nil", err)
+	}
+}
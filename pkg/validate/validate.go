@@ -22,12 +22,37 @@
 
 package validate
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const tagName = "validate"
+
+var (
+	ErrUnknownRule     = errors.New("unknown validation rule")
+	ErrUnsupportedType = errors.New("unsupported value type")
+)
+
 type Validate struct {
 	rules []ValidationRule
 }
 
 type ValidationRule func(interface{}) error
 
+// RuleFactory builds a named rule from its parameter, e.g. "10.0.0.0/8"
+// for the tag `validate:"ip_in=10.0.0.0/8"`. Rules without parameters
+// receive an empty string.
+type RuleFactory func(param string) (ValidationRule, error)
+
+var ruleRegistry = struct {
+	sync.RWMutex
+	factories map[string]RuleFactory
+}{factories: make(map[string]RuleFactory)}
+
 func NewValidate() *Validate {
 	return &Validate{}
 }
@@ -36,6 +61,17 @@ func (v *Validate) AddRule(rule ValidationRule) {
 	v.rules = append(v.rules, rule)
 }
 
+// AddNamedRule adds the registered rule described by tag, using the same
+// "name" or "name=param" syntax as a single struct tag entry.
+func (v *Validate) AddNamedRule(tag string) error {
+	rule, err := lookupRule(tag)
+	if err != nil {
+		return err
+	}
+	v.AddRule(rule)
+	return nil
+}
+
 func (v *Validate) Validate(value interface{}) error {
 	for _, rule := range v.rules {
 		if err := rule(value); err != nil {
@@ -44,3 +80,143 @@ func (v *Validate) Validate(value interface{}) error {
 	}
 	return nil
 }
+
+// RegisterRule makes a rule available by name to AddNamedRule and to
+// struct tags. Registering an existing name replaces it.
+func RegisterRule(name string, factory RuleFactory) {
+	ruleRegistry.Lock()
+	defer ruleRegistry.Unlock()
+	ruleRegistry.factories[name] = factory
+}
+
+// StringRule adapts a string validator such as EmailIsValid to a
+// ValidationRule that fails with err.
+func StringRule(fn func(string) bool, err error) ValidationRule {
+	return func(value interface{}) error {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %T, want string", ErrUnsupportedType, value)
+		}
+		if !fn(s) {
+			return err
+		}
+		return nil
+	}
+}
+
+// stringRuleFactory registers a string validator that takes no parameter.
+func stringRuleFactory(fn func(string) bool, err error) RuleFactory {
+	rule := StringRule(fn, err)
+	return func(string) (ValidationRule, error) {
+		return rule, nil
+	}
+}
+
+type FieldError struct {
+	Field string
+	Rule  string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Field, e.Rule, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e FieldErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStruct validates the exported fields of s, a struct or a pointer
+// to one, against the registered rules named in their `validate` tags,
+// e.g. `validate:"ipv4,ip_in=10.0.0.0/8|192.168.0.0/16"`. Nested structs
+// are validated as well. It returns nil or a FieldErrors with one entry
+// per failed field rule.
+func ValidateStruct(s interface{}) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T, want struct", ErrUnsupportedType, s)
+	}
+
+	var errs FieldErrors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *FieldErrors) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := prefix + field.Name
+		value := rv.Field(i)
+
+		if tag := field.Tag.Get(tagName); tag != "" && tag != "-" {
+			for _, entry := range strings.Split(tag, ",") {
+				rule, err := lookupRule(entry)
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				if err := rule(value.Interface()); err != nil {
+					ruleName, _, _ := strings.Cut(entry, "=")
+					*errs = append(*errs, &FieldError{Field: name, Rule: ruleName, Err: err})
+				}
+			}
+		}
+
+		for value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			if err := validateStruct(value, name+".", errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func lookupRule(tag string) (ValidationRule, error) {
+	name, param, _ := strings.Cut(strings.TrimSpace(tag), "=")
+
+	ruleRegistry.RLock()
+	factory, ok := ruleRegistry.factories[name]
+	ruleRegistry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRule, name)
+	}
+
+	rule, err := factory(param)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", name, err)
+	}
+	return rule, nil
+}
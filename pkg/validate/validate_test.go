@@ -179,3 +179,194 @@ func TestValidate_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_AddNamedRule(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag   string
+		value interface{}
+	}
+
+	type want struct {
+		err    error
+		addErr error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid value",
+			in: in{
+				tag:   "ipv4",
+				value: "192.0.2.1",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "invalid value",
+			in: in{
+				tag:   "ipv4",
+				value: "2001:db8::1",
+			},
+			want: want{
+				err: validate.ErrIPv4Invalid,
+			},
+		},
+		{
+			name: "rule with parameter",
+			in: in{
+				tag:   "ip_in=10.0.0.0/8|192.168.0.0/16",
+				value: "172.16.0.1",
+			},
+			want: want{
+				err: validate.ErrIPNotInRange,
+			},
+		},
+		{
+			name: "unsupported type",
+			in: in{
+				tag:   "ip",
+				value: 42,
+			},
+			want: want{
+				err: validate.ErrUnsupportedType,
+			},
+		},
+		{
+			name: "invalid parameter",
+			in: in{
+				tag: "ip_in=10.0.0.0",
+			},
+			want: want{
+				addErr: validate.ErrCIDRInvalid,
+			},
+		},
+		{
+			name: "unknown rule",
+			in: in{
+				tag: "no_such_rule",
+			},
+			want: want{
+				addErr: validate.ErrUnknownRule,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validate.NewValidate()
+			err := v.AddNamedRule(tt.in.tag)
+			if !errors.Is(err, tt.want.addErr) {
+				t.Fatalf("AddNamedRule(%q) error = %v, want %v", tt.in.tag, err, tt.want.addErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := v.Validate(tt.in.value); !errors.Is(err, tt.want.err) {
+				t.Errorf("Validate(%v) error = %v, want %v", tt.in.value, err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd length")
+	validate.RegisterRule("test_even_length", func(string) (validate.ValidationRule, error) {
+		return validate.StringRule(func(s string) bool { return len(s)%2 == 0 }, errOdd), nil
+	})
+
+	type upstream struct {
+		Addr string `validate:"ip"`
+	}
+
+	type config struct {
+		Listen   string   `validate:"ip_in=127.0.0.0/8|::1/128"`
+		Subnet   string   `validate:"cidr"`
+		Code     string   `validate:"test_even_length"`
+		Upstream upstream `validate:""`
+		Backup   *upstream
+		internal string `validate:"ip"`
+	}
+
+	type in struct {
+		value interface{}
+	}
+
+	type want struct {
+		fields []string
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid struct",
+			in: in{
+				value: config{Listen: "127.0.0.1", Subnet: "10.0.0.0/8", Code: "ab", Upstream: upstream{Addr: "::1"}},
+			},
+			want: want{},
+		},
+		{
+			name: "invalid fields",
+			in: in{
+				value: &config{
+					Listen:   "0.0.0.0",
+					Subnet:   "10.0.0.0",
+					Code:     "abc",
+					Upstream: upstream{Addr: "::1"},
+					Backup:   &upstream{Addr: "backup"},
+					internal: "ignored",
+				},
+			},
+			want: want{
+				fields: []string{"Listen", "Subnet", "Code", "Backup.Addr"},
+				err:    errOdd,
+			},
+		},
+		{
+			name: "not a struct",
+			in: in{
+				value: "127.0.0.1",
+			},
+			want: want{
+				err: validate.ErrUnsupportedType,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.ValidateStruct(tt.in.value)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("ValidateStruct() error = %v, want %v", err, tt.want.err)
+			}
+
+			var errs validate.FieldErrors
+			if !errors.As(err, &errs) {
+				if len(tt.want.fields) > 0 {
+					t.Fatalf("ValidateStruct() error = %v, want field errors %v", err, tt.want.fields)
+				}
+				return
+			}
+			if len(errs) != len(tt.want.fields) {
+				t.Fatalf("ValidateStruct() = %v, want errors for %v", errs, tt.want.fields)
+			}
+			for i, f := range tt.want.fields {
+				if errs[i].Field != f {
+					t.Errorf("ValidateStruct() error %d for %q, want %q", i, errs[i].Field, f)
+				}
+			}
+		})
+	}
+}